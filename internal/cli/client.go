@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"gitsentry/internal/control"
+	"gitsentry/internal/core"
+)
+
+func daemonClient() *control.Client {
+	return control.NewClient(control.SocketPath(".gitsentry"))
+}
+
+func fetchStatus() (*core.Status, bool, error) {
+	var live core.Status
+	if err := daemonClient().Call(control.Request{Command: control.CommandStatus}, &live); err == nil {
+		return &live, true, nil
+	}
+	
+	sentry := core.NewGitSentry(".")
+	status, err := sentry.GetStatus()
+	return status, false, err
+}
+
+func statusSource(live bool) string {
+	if live {
+		return "live daemon"
+	}
+	return "state on disk (daemon not reachable)"
+}
@@ -2,8 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"gitsentry/internal/config"
 	"gitsentry/internal/core"
 )
 
@@ -30,4 +32,36 @@ var configCmd = &cobra.Command{
 		
 		return nil
 	},
-}
\ No newline at end of file
+}
+
+var configExplainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Show where each effective config value comes from",
+	Long: `Show every effective configuration value together with the layer that set it.
+
+Layers are applied in this order, later ones winning:
+  default    Built-in defaults
+  global     ~/.config/gitsentry/config.yaml (or $XDG_CONFIG_HOME/gitsentry)
+  shared     .gitsentry.yaml at the repository root (commit this one)
+  local      .gitsentry/config.yaml (gitignored, personal to this clone)
+  env        GITSENTRY_* environment variables, e.g. GITSENTRY_RULES_MAX_FILES_CHANGED
+  flag       --set key=value on the command line`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		layered, err := config.LoadLayered(filepath.Join(".", ".gitsentry"))
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		
+		PrintHeader("GitSentry Effective Configuration")
+		
+		for _, setting := range layered.Explain() {
+			fmt.Printf("%-40s %-20s %s\n", setting.Key, setting.Value, setting.Source)
+		}
+		
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configExplainCmd)
+}
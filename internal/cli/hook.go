@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/core"
+	"gitsentry/internal/gate"
+	"gitsentry/internal/hooks"
+)
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Run the checks behind a GitSentry git hook",
+	Long: `Run the checks that the git hooks installed by 'gitsentry hooks install'
+call into. These commands are meant to be run by git, but can be run by
+hand to preview what a hook would report.`,
+}
+
+var hookPreCommitCmd = &cobra.Command{
+	Use:   "pre-commit",
+	Short: "Check the staged changes for secrets and against the commit gate",
+	Long: `Scan the staged content for secrets (see 'gitsentry secrets'), then measure
+the staged changes (files, changed lines, binary files and file sizes)
+against the commit_gate settings. Limits left at 0 fall back to
+rules.max_files_changed and rules.max_lines_changed.
+
+Each check follows its own mode setting (secrets.mode, commit_gate.mode).
+In "warn" mode problems are reported and the commit goes ahead. In "block"
+mode the commit is rejected unless GITSENTRY_BYPASS is set to a reason,
+which is recorded in .gitsentry/logs/audit.log.
+
+Examples:
+  gitsentry hook pre-commit
+  GITSENTRY_BYPASS="vendored dependency update" git commit`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sentry := core.NewGitSentry(".")
+		
+		findings, err := sentry.CheckStagedSecrets()
+		if err != nil {
+			return err
+		}
+		
+		err = enforce(sentry, hooks.PreCommit, findings,
+			"Remove the secrets, allowlist their fingerprints under secrets.allow_fingerprints",
+			"commit blocked by the secret scanner")
+		if err != nil {
+			return err
+		}
+		
+		report, err := sentry.CheckCommitGate()
+		if err != nil {
+			return err
+		}
+		
+		return enforce(sentry, hooks.PreCommit, report,
+			"Split the commit",
+			fmt.Sprintf("commit blocked by the commit gate (%d files, %d lines staged)", report.Files, report.Lines))
+	},
+}
+
+var hookPrePushCmd = &cobra.Command{
+	Use:   "pre-push <remote> [<url>]",
+	Short: "Check the refs being pushed against the push policy",
+	Long: `Read the refs being pushed from standard input, in the format git passes
+to pre-push hooks, and check them against the push_policy settings:
+
+  - no pushes to protected_branches
+  - no WIP, fixup!, squash! or amend! commits on shared_branches
+  - a Signed-off-by from the author on every commit when require_signoff is set
+  - at most max_commits commits per pushed branch
+
+Only the local repository is inspected, nothing is fetched. In "block" mode
+the push is rejected unless GITSENTRY_BYPASS is set to a reason, which is
+recorded in .gitsentry/logs/audit.log.
+
+Examples:
+  echo "refs/heads/main $(git rev-parse main) refs/heads/main $(git rev-parse origin/main)" | gitsentry hook pre-push origin
+  GITSENTRY_BYPASS="hotfix for incident 42" git push origin main`,
+	Args:          cobra.RangeArgs(1, 2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		updates, err := gate.ParseRefUpdates(os.Stdin)
+		if err != nil {
+			return err
+		}
+		
+		sentry := core.NewGitSentry(".")
+		
+		report, err := sentry.CheckPushPolicy(args[0], updates)
+		if err != nil {
+			return err
+		}
+		
+		return enforce(sentry, hooks.PrePush, report,
+			"Fix the commits",
+			"push blocked by the push policy")
+	},
+}
+
+func enforce(sentry *core.GitSentry, hook string, report *gate.Report, advice, blocked string) error {
+	if len(report.Violations) == 0 {
+		return nil
+	}
+	
+	label := "warning"
+	if report.Mode == gate.ModeBlock {
+		label = "error"
+	}
+	for _, violation := range report.Violations {
+		fmt.Fprintf(os.Stderr, "GitSentry %s: %s\n", label, violation)
+	}
+	
+	if !report.Blocked() {
+		return nil
+	}
+	
+	if reason, ok := gate.Bypass(); ok {
+		if err := sentry.RecordBypass(hook, reason, report.Violations); err != nil {
+			return fmt.Errorf("failed to record bypass, %s not allowed: %w", hook, err)
+		}
+		fmt.Fprintf(os.Stderr, "GitSentry: %s check bypassed (%s), recorded in the audit log\n", hook, reason)
+		return nil
+	}
+	
+	fmt.Fprintf(os.Stderr, "%s, or bypass the check once with %s=\"<reason>\"\n", advice, gate.BypassEnv)
+	return errors.New(blocked)
+}
+
+func init() {
+	hookCmd.AddCommand(hookPreCommitCmd)
+	hookCmd.AddCommand(hookPrePushCmd)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/git"
+	"gitsentry/internal/hooks"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage GitSentry git hooks",
+	Long: `Install or remove the git hooks that enforce GitSentry policies at commit time.
+
+Existing hooks are kept: they are renamed to <hook>.gitsentry-chained and
+run before the GitSentry check.
+
+Examples:
+  gitsentry hooks install            Install hooks in the current repository
+  gitsentry hooks uninstall          Remove them and restore previous hooks`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install GitSentry git hooks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := hooksDir()
+		if err != nil {
+			return err
+		}
+		
+		executable, err := os.Executable()
+		if err != nil {
+			executable = "gitsentry"
+		}
+		
+		results, err := hooks.Install(dir, executable)
+		if err != nil {
+			return err
+		}
+		
+		for _, result := range results {
+			action := "Installed"
+			if result.Updated {
+				action = "Updated"
+			}
+			fmt.Printf("%s %s hook: %s\n", action, result.Name, result.Path)
+			if result.Chained {
+				fmt.Printf("  chains to existing hook %s%s\n", result.Path, hooks.ChainSuffix)
+			}
+		}
+		
+		return nil
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove GitSentry git hooks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := hooksDir()
+		if err != nil {
+			return err
+		}
+		
+		removed, err := hooks.Uninstall(dir)
+		if err != nil {
+			return err
+		}
+		
+		if len(removed) == 0 {
+			fmt.Println("No GitSentry hooks installed")
+			return nil
+		}
+		
+		for _, name := range removed {
+			fmt.Printf("Removed %s hook\n", name)
+		}
+		
+		return nil
+	},
+}
+
+func hooksDir() (string, error) {
+	repo, err := git.NewRepository(".")
+	if err != nil {
+		return "", err
+	}
+	
+	dir, err := repo.HooksDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate hooks directory: %w", err)
+	}
+	
+	return dir, nil
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+}
@@ -23,10 +23,16 @@ Available templates:
   • strict   - Very strict settings for critical projects
   • relaxed  - Relaxed settings for experimental work
 
+Your own templates can live in ~/.config/gitsentry/templates/<name>.yaml,
+or be passed as a path. A template file may set "extends: <template>" to
+inherit from another template and override only some fields.
+
 Examples:
   gitsentry init                     Initialize with default settings
   gitsentry init --template=team     Initialize with team template
-  gitsentry init --template=strict   Initialize with strict rules`,
+  gitsentry init --template=strict   Initialize with strict rules
+  gitsentry init --template=backend  Initialize with ~/.config/gitsentry/templates/backend.yaml
+  gitsentry init --template=./ci/gitsentry-template.yaml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		sentry := core.NewGitSentry(".")
 		
@@ -44,5 +50,5 @@ Examples:
 }
 
 func init() {
-	initCmd.Flags().StringVar(&initTemplate, "template", "", "Configuration template (team, strict, relaxed)")
+	initCmd.Flags().StringVar(&initTemplate, "template", "", "Configuration template name or file (team, strict, relaxed, or your own)")
 }
\ No newline at end of file
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/core"
+	"gitsentry/internal/message"
+)
+
+var suggestMessageCmd = &cobra.Command{
+	Use:   "suggest-message",
+	Short: "Draft a commit message from the current changes",
+	Long: `Draft a commit message from the working tree diff against HEAD.
+
+The type (feat, fix, docs, test, chore or refactor) is guessed from the
+changed paths and the kind of change, and the scope from the top-level
+directory. The body lists touched files and symbols. The draft follows
+commit_message_format ("conventional" or "simple") and is never committed
+for you.
+
+Examples:
+  gitsentry suggest-message
+  gitsentry suggest-message --set commit_message_format=simple
+  gitsentry suggest-message > .git/COMMIT_DRAFT && git commit -e -F .git/COMMIT_DRAFT`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		draft, err := core.NewGitSentry(".").DraftCommitMessage()
+		if err != nil {
+			return fmt.Errorf("failed to draft commit message: %w", err)
+		}
+		
+		fmt.Print(draft)
+		return nil
+	},
+}
+
+var lintMessageCmd = &cobra.Command{
+	Use:   "lint-message <file>",
+	Short: "Check a commit message against the configured format",
+	Long: `Check a commit message file against commit_message_format and the
+commit_lint settings. This is what the commit-msg hook installed by
+'gitsentry hooks install' runs.
+
+Examples:
+  gitsentry lint-message .git/COMMIT_EDITMSG`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read commit message: %w", err)
+		}
+		
+		cfg, err := core.NewGitSentry(".").GetConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		
+		problems, err := message.Lint(string(data), cfg.CommitMessageFormat, cfg.CommitLint)
+		if err != nil {
+			return err
+		}
+		
+		if len(problems) == 0 {
+			return nil
+		}
+		
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "%s:%s\n", args[0], problem)
+			if pointer := problem.Pointer(); pointer != "" {
+				fmt.Fprintf(os.Stderr, "  %s\n", strings.ReplaceAll(pointer, "\n", "\n  "))
+			}
+		}
+		fmt.Fprintln(os.Stderr, "Fix the message, or skip the check once with 'git commit --no-verify'")
+		
+		noun := "problems"
+		if len(problems) == 1 {
+			noun = "problem"
+		}
+		
+		return fmt.Errorf("commit message does not follow the %s format (%d %s)", cfg.CommitMessageFormat, len(problems), noun)
+	},
+}
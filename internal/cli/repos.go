@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/control"
+	"gitsentry/internal/core"
+	"gitsentry/internal/registry"
+	"gitsentry/internal/security"
+)
+
+var reposCmd = &cobra.Command{
+	Use:   "repos",
+	Short: "Manage repositories monitored by the supervisor",
+	Long: `Manage the registry of repositories hosted by 'gitsentry supervisor'.
+
+Examples:
+  gitsentry repos add                Register the current repository
+  gitsentry repos add ~/project-2    Register another repository
+  gitsentry repos remove ~/project-2 Stop monitoring a repository
+  gitsentry repos list               Show registered repositories`,
+}
+
+var reposAddCmd = &cobra.Command{
+	Use:   "add [path]",
+	Short: "Register a repository with the supervisor",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := registry.Load()
+		if err != nil {
+			return err
+		}
+		
+		path, err := reg.Add(repoArg(args))
+		if err != nil {
+			return err
+		}
+		
+		if err := security.AllowRoot(path); err != nil {
+			return err
+		}
+		
+		if _, err := os.Stat(filepath.Join(path, ".gitsentry")); os.IsNotExist(err) {
+			if err := core.NewGitSentry(path).Initialize(); err != nil {
+				return fmt.Errorf("failed to initialize GitSentry in %s: %w", path, err)
+			}
+			PrintInfo(fmt.Sprintf("Initialized GitSentry in %s", path))
+		}
+		
+		if err := reg.Save(); err != nil {
+			return fmt.Errorf("failed to save registry: %w", err)
+		}
+		
+		PrintSuccess(fmt.Sprintf("Registered %s", path))
+		printSupervisorHint()
+		return nil
+	},
+}
+
+var reposRemoveCmd = &cobra.Command{
+	Use:   "remove [path]",
+	Short: "Unregister a repository from the supervisor",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := registry.Load()
+		if err != nil {
+			return err
+		}
+		
+		path, removed := reg.Remove(repoArg(args))
+		if !removed {
+			return fmt.Errorf("repository not registered: %s", path)
+		}
+		
+		if err := reg.Save(); err != nil {
+			return fmt.Errorf("failed to save registry: %w", err)
+		}
+		
+		PrintSuccess(fmt.Sprintf("Unregistered %s", path))
+		printSupervisorHint()
+		return nil
+	},
+}
+
+var reposListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered repositories",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := registry.Load()
+		if err != nil {
+			return err
+		}
+		
+		PrintHeader("Registered Repositories")
+		
+		if len(reg.Repos) == 0 {
+			PrintInfo("No repositories registered (use 'gitsentry repos add')")
+			return nil
+		}
+		
+		for _, entry := range reg.Repos {
+			fmt.Println(FormatKeyValue(entry.Path, repoState(entry.Path)))
+		}
+		
+		fmt.Println()
+		fmt.Println(FormatKeyValue("Supervisor", FormatStatus(supervisorRunning(), "Running", "Stopped")))
+		fmt.Println(FormatKeyValue("Registry", reg.Path()))
+		return nil
+	},
+}
+
+func repoArg(args []string) string {
+	if len(args) == 0 {
+		return "."
+	}
+	return args[0]
+}
+
+func repoState(path string) string {
+	if _, err := os.Stat(filepath.Join(path, ".gitsentry")); err != nil {
+		return "not initialized"
+	}
+	
+	client := control.NewClient(control.SocketPath(filepath.Join(path, ".gitsentry")))
+	
+	var status core.Status
+	if err := client.Call(control.Request{Command: control.CommandStatus}, &status); err != nil {
+		return "not monitored"
+	}
+	
+	return fmt.Sprintf("monitoring (%d files changed)", status.FilesChanged)
+}
+
+func printSupervisorHint() {
+	if supervisorRunning() {
+		PrintInfo("The running supervisor will apply this change on its next scheduler tick")
+	} else {
+		PrintInfo("Use 'gitsentry supervisor start' to monitor registered repositories")
+	}
+}
+
+func init() {
+	reposCmd.AddCommand(reposAddCmd)
+	reposCmd.AddCommand(reposRemoveCmd)
+	reposCmd.AddCommand(reposListCmd)
+}
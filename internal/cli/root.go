@@ -2,8 +2,11 @@ package cli
 
 import (
 	"github.com/spf13/cobra"
+	"gitsentry/internal/config"
 )
 
+var configOverrides []string
+
 var rootCmd = &cobra.Command{
 	Use:     "gitsentry",
 	Version: "1.0.0",
@@ -25,6 +28,9 @@ Examples:
   gitsentry rules --interactive      Configure rules interactively
   gitsentry stats --export=json      Export statistics to JSON
   gitsentry doctor                   Run health diagnostics`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.SetOverrides(configOverrides)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		PrintHeader("GitSentry - Your Git Workflow Assistant")
 		PrintInfo("Use 'gitsentry --help' to see all available commands")
@@ -36,7 +42,17 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+func detachArgs(args ...string) []string {
+	for _, assignment := range configOverrides {
+		args = append(args, "--set", assignment)
+	}
+	
+	return args
+}
+
 func init() {
+	rootCmd.PersistentFlags().StringArrayVar(&configOverrides, "set", nil, "Override a config value for this run (key=value, repeatable)")
+	
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(stopCmd)
@@ -45,4 +61,12 @@ func init() {
 	rootCmd.AddCommand(rulesCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(reposCmd)
+	rootCmd.AddCommand(supervisorCmd)
+	rootCmd.AddCommand(snoozeCmd)
+	rootCmd.AddCommand(suggestMessageCmd)
+	rootCmd.AddCommand(lintMessageCmd)
+	rootCmd.AddCommand(hooksCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(secretsCmd)
 }
\ No newline at end of file
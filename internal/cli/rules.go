@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"gitsentry/internal/core"
+	"gitsentry/internal/rules"
 )
 
 var (
@@ -41,6 +42,29 @@ var rulesCmd = &cobra.Command{
 		fmt.Printf("Auto-suggest pushes: %t\n", config.AutoSuggestPushes)
 		fmt.Printf("Commit message format: %s\n", config.CommitMessageFormat)
 		
+		fmt.Println("\nSuggestion rules:")
+		for _, id := range rules.Registered() {
+			state := "enabled"
+			if !rules.IsEnabled(config, id) {
+				state = "disabled"
+			}
+			fmt.Printf("  %s: %s\n", id, state)
+		}
+		
+		if len(config.Overrides) > 0 {
+			fmt.Println("\nOverrides:")
+			for _, override := range config.Overrides {
+				var scope []string
+				if len(override.Paths) > 0 {
+					scope = append(scope, "paths "+strings.Join(override.Paths, ", "))
+				}
+				if len(override.Branches) > 0 {
+					scope = append(scope, "branches "+strings.Join(override.Branches, ", "))
+				}
+				fmt.Printf("  %s: %s\n", rules.ScopeName(override), strings.Join(scope, "; "))
+			}
+		}
+		
 		return nil
 	},
 }
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/core"
+)
+
+var scanAllFiles bool
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Scan changes for secrets and manage the secrets baseline",
+	Long: `Look for AWS keys, private key headers, JSON web tokens and high-entropy
+secret assignments in the files you change. The monitor reports findings as
+high-severity suggestions and the pre-commit hook scans the staged content.
+
+Findings can be suppressed by path (secrets.allow_paths), by fingerprint
+(secrets.allow_fingerprints) or by recording them in
+.gitsentry/secrets-baseline.json with 'gitsentry secrets baseline'.
+
+Examples:
+  gitsentry secrets scan             Scan files changed in the working tree
+  gitsentry secrets scan --all       Scan every tracked and untracked file
+  gitsentry secrets baseline         Accept all current findings`,
+}
+
+var secretsScanCmd = &cobra.Command{
+	Use:           "scan",
+	Short:         "Scan files for secrets",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		findings, err := core.NewGitSentry(".").ScanSecrets(scanAllFiles)
+		if err != nil {
+			return err
+		}
+		
+		if len(findings) == 0 {
+			fmt.Println("No secrets found")
+			return nil
+		}
+		
+		for _, finding := range findings {
+			fmt.Fprintln(os.Stderr, finding)
+		}
+		
+		noun := "secrets"
+		if len(findings) == 1 {
+			noun = "secret"
+		}
+		
+		return fmt.Errorf("found %d possible %s", len(findings), noun)
+	},
+}
+
+var secretsBaselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Record current findings in the secrets baseline",
+	Long: `Scan every tracked and untracked file and write the findings to
+.gitsentry/secrets-baseline.json, replacing its contents. Findings in the
+baseline are no longer reported. Only fingerprints and locations are
+stored, never the secrets themselves.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count, err := core.NewGitSentry(".").WriteSecretsBaseline()
+		if err != nil {
+			return err
+		}
+		
+		fmt.Printf("Recorded %d findings in .gitsentry/secrets-baseline.json\n", count)
+		return nil
+	},
+}
+
+func init() {
+	secretsScanCmd.Flags().BoolVar(&scanAllFiles, "all", false, "Scan all tracked and untracked files, not only changed ones")
+	secretsCmd.AddCommand(secretsScanCmd)
+	secretsCmd.AddCommand(secretsBaselineCmd)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/control"
+	"gitsentry/internal/core"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [duration|until-commit|off]",
+	Short: "Silence GitSentry suggestions for this repository",
+	Long: `Temporarily silence commit and push suggestions for this repository.
+
+The snooze is stored with the repository state, so a running daemon
+picks it up immediately and a later start keeps honouring it.
+
+Examples:
+  gitsentry snooze 45m               Stay quiet for 45 minutes
+  gitsentry snooze until-commit      Stay quiet until the next commit
+  gitsentry snooze off               Resume suggestions now`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var status core.SnoozeStatus
+		
+		err := daemonClient().Call(control.Request{Command: control.CommandSnooze, Duration: args[0]}, &status)
+		if errors.Is(err, control.ErrUnavailable) {
+			status, err = core.NewGitSentry(".").Snooze(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to snooze: %w", err)
+		}
+		
+		if status.Active() {
+			fmt.Printf("Suggestions snoozed %s\n", status)
+		} else {
+			fmt.Println("Suggestions resumed")
+		}
+		
+		return nil
+	},
+}
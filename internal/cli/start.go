@@ -5,13 +5,17 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gitsentry/internal/core"
+	"gitsentry/internal/daemon"
 )
 
 var (
-	daemonMode bool
+	daemonMode   bool
+	foreground   bool
+	readyTimeout time.Duration
 )
 
 var startCmd = &cobra.Command{
@@ -26,12 +30,27 @@ Modes:
 
 Examples:
   gitsentry start                    Start interactive monitoring
-  gitsentry start --daemon           Start background daemon mode`,
+  gitsentry start --daemon           Start background daemon mode
+  gitsentry start --daemon --foreground
+                                     Run daemon mode attached for debugging`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		sentry := core.NewGitSentry(".")
 		
 		if daemonMode {
-			return sentry.StartDaemon()
+			if foreground || daemon.IsDetachedChild() {
+				return sentry.StartDaemon()
+			}
+			
+			d := daemon.NewDaemon(".")
+			pid, err := d.Detach(detachArgs("start", "--daemon"), readyTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to start daemon: %w", err)
+			}
+			
+			PrintSuccess(fmt.Sprintf("GitSentry daemon started (PID %d)", pid))
+			PrintInfo(fmt.Sprintf("Logs: %s", d.LogFile()))
+			PrintInfo("Use 'gitsentry stop' to stop monitoring")
+			return nil
 		}
 		
 		if err := sentry.Start(); err != nil {
@@ -43,7 +62,10 @@ Examples:
 		
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-		<-c
+		select {
+		case <-c:
+		case <-sentry.ShutdownRequested():
+		}
 		
 		PrintInfo("Stopping GitSentry...")
 		sentry.Stop()
@@ -55,4 +77,6 @@ Examples:
 
 func init() {
 	startCmd.Flags().BoolVar(&daemonMode, "daemon", false, "Run in background daemon mode")
+	startCmd.Flags().BoolVar(&foreground, "foreground", false, "Keep daemon mode attached to the terminal for debugging")
+	startCmd.Flags().DurationVar(&readyTimeout, "ready-timeout", daemon.DefaultTimeout, "How long to wait for the daemon to become ready")
 }
\ No newline at end of file
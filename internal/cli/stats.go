@@ -27,6 +27,7 @@ type StatsExport struct {
 	LastCommit      string    `json:"last_commit"`
 	LastPush        string    `json:"last_push"`
 	UnpushedCommits int       `json:"unpushed_commits"`
+	Source          string    `json:"source"`
 }
 
 var statsCmd = &cobra.Command{
@@ -34,15 +35,13 @@ var statsCmd = &cobra.Command{
 	Short: "Display and export GitSentry statistics",
 	Long:  `Show current GitSentry statistics and optionally export to JSON format.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		sentry := core.NewGitSentry(".")
-		
-		status, err := sentry.GetStatus()
+		status, live, err := fetchStatus()
 		if err != nil {
 			return fmt.Errorf("failed to get status: %w", err)
 		}
 		
 		if exportFormat == "json" {
-			return exportStatsJSON(status)
+			return exportStatsJSON(status, live)
 		}
 		
 		fmt.Println("GitSentry Statistics")
@@ -56,12 +55,13 @@ var statsCmd = &cobra.Command{
 		fmt.Printf("Last commit: %s\n", status.LastCommit)
 		fmt.Printf("Last push: %s\n", status.LastPush)
 		fmt.Printf("Unpushed commits: %d\n", status.UnpushedCommits)
+		fmt.Printf("Source: %s\n", statusSource(live))
 		
 		return nil
 	},
 }
 
-func exportStatsJSON(status *core.Status) error {
+func exportStatsJSON(status *core.Status, live bool) error {
 	export := StatsExport{
 		Timestamp:       time.Now(),
 		RepoPath:        status.RepoPath,
@@ -73,6 +73,7 @@ func exportStatsJSON(status *core.Status) error {
 		LastCommit:      status.LastCommit,
 		LastPush:        status.LastPush,
 		UnpushedCommits: status.UnpushedCommits,
+		Source:          statusSource(live),
 	}
 	
 	data, err := json.MarshalIndent(export, "", "  ")
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"gitsentry/internal/core"
 )
 
 var statusCmd = &cobra.Command{
@@ -12,9 +11,7 @@ var statusCmd = &cobra.Command{
 	Short: "Show GitSentry status and repository information",
 	Long:  `Display current GitSentry status, repository state, and monitoring statistics.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		sentry := core.NewGitSentry(".")
-		
-		status, err := sentry.GetStatus()
+		status, live, err := fetchStatus()
 		if err != nil {
 			return fmt.Errorf("failed to get status: %w", err)
 		}
@@ -30,6 +27,11 @@ var statusCmd = &cobra.Command{
 		fmt.Println(FormatKeyValue("Last commit", status.LastCommit))
 		fmt.Println(FormatKeyValue("Last push", status.LastPush))
 		fmt.Println(FormatKeyValue("Unpushed commits", fmt.Sprintf("%d", status.UnpushedCommits)))
+		fmt.Println(FormatKeyValue("Snoozed", status.Snooze.String()))
+		if status.UnwatchedDirs > 0 {
+			PrintWarning(fmt.Sprintf("%d directories are not watched because the file watch budget is exhausted", status.UnwatchedDirs))
+		}
+		fmt.Println(FormatKeyValue("Source", statusSource(live)))
 		
 		return nil
 	},
@@ -2,23 +2,63 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
-	"gitsentry/internal/core"
+	"gitsentry/internal/control"
+	"gitsentry/internal/daemon"
+)
+
+var (
+	stopTimeout time.Duration
+	stopForce   bool
 )
 
 var stopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop GitSentry monitoring",
-	Long:  `Stop GitSentry background monitoring if it's currently running.`,
+	Long: `Stop the GitSentry background daemon if it's currently running.
+
+The daemon is asked to shut down and given time to flush its state.
+A repository hosted by the supervisor has no PID file of its own; it is
+asked to stop through its control socket instead.
+If it does not exit within the timeout, --force escalates to SIGKILL.
+
+Examples:
+  gitsentry stop                     Stop the daemon gracefully
+  gitsentry stop --timeout=30s       Wait longer for a clean shutdown
+  gitsentry stop --force             Kill the daemon if it does not exit`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		sentry := core.NewGitSentry(".")
+		d := daemon.NewDaemon(".")
 		
-		if err := sentry.Stop(); err != nil {
+		result, err := d.Stop(stopTimeout, stopForce)
+		if err != nil {
 			return fmt.Errorf("failed to stop GitSentry: %w", err)
 		}
 		
-		PrintSuccess("GitSentry stopped")
+		if result.Outcome == daemon.StopNotRunning || result.Outcome == daemon.StopStalePID {
+			if err := daemonClient().Call(control.Request{Command: control.CommandShutdown}, nil); err == nil {
+				PrintSuccess("GitSentry stopped monitoring this repository and removed it from the supervisor")
+				return nil
+			}
+		}
+		
+		switch result.Outcome {
+		case daemon.StopNotRunning:
+			PrintInfo("GitSentry daemon is not running")
+		case daemon.StopStalePID:
+			PrintWarning("GitSentry daemon was not running, removed stale PID file")
+		case daemon.StopGraceful:
+			PrintSuccess(fmt.Sprintf("GitSentry daemon stopped (PID %d)", result.PID))
+		case daemon.StopKilled:
+			PrintWarning(fmt.Sprintf("GitSentry daemon did not exit within %s and was killed (PID %d)", stopTimeout, result.PID))
+		}
+		
 		return nil
 	},
-}
\ No newline at end of file
+}
+
+func init() {
+	stopCmd.Flags().DurationVar(&stopTimeout, "timeout", daemon.DefaultTimeout, "How long to wait for the daemon to exit")
+	stopCmd.Flags().BoolVar(&stopForce, "force", false, "Kill the daemon if it does not exit within the timeout")
+}
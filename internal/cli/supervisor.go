@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gitsentry/internal/config"
+	"gitsentry/internal/daemon"
+	"gitsentry/internal/logger"
+	"gitsentry/internal/registry"
+	"gitsentry/internal/security"
+	"gitsentry/internal/supervisor"
+)
+
+var (
+	supervisorForeground   bool
+	supervisorReadyTimeout time.Duration
+	supervisorStopTimeout  time.Duration
+	supervisorStopForce    bool
+)
+
+var supervisorCmd = &cobra.Command{
+	Use:   "supervisor",
+	Short: "Run one daemon that monitors every registered repository",
+	Long: `Run a single per-user GitSentry daemon that hosts every repository in the
+registry managed by 'gitsentry repos'. All repositories share one file watch
+budget and one scheduler, while each keeps its own config and state.
+
+Examples:
+  gitsentry repos add ~/project-1    Register a repository
+  gitsentry supervisor start         Start the supervisor in the background
+  gitsentry supervisor stop          Stop the supervisor`,
+}
+
+var supervisorStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the supervisor daemon",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configDir, err := supervisorConfigDir()
+		if err != nil {
+			return err
+		}
+		
+		d := daemon.NewSupervisorDaemon(configDir)
+		
+		if supervisorForeground || daemon.IsDetachedChild() {
+			return runSupervisor(configDir, d)
+		}
+		
+		pid, err := d.Detach(detachArgs("supervisor", "start"), supervisorReadyTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to start supervisor: %w", err)
+		}
+		
+		PrintSuccess(fmt.Sprintf("GitSentry supervisor started (PID %d)", pid))
+		PrintInfo(fmt.Sprintf("Logs: %s", d.LogFile()))
+		return nil
+	},
+}
+
+var supervisorStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the supervisor daemon",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configDir, err := supervisorConfigDir()
+		if err != nil {
+			return err
+		}
+		
+		d := daemon.NewSupervisorDaemon(configDir)
+		
+		result, err := d.Stop(supervisorStopTimeout, supervisorStopForce)
+		if err != nil {
+			return fmt.Errorf("failed to stop supervisor: %w", err)
+		}
+		
+		switch result.Outcome {
+		case daemon.StopNotRunning:
+			PrintInfo("GitSentry supervisor is not running")
+		case daemon.StopStalePID:
+			PrintWarning("GitSentry supervisor was not running, removed stale PID file")
+		case daemon.StopGraceful:
+			PrintSuccess(fmt.Sprintf("GitSentry supervisor stopped (PID %d)", result.PID))
+		case daemon.StopKilled:
+			PrintWarning(fmt.Sprintf("GitSentry supervisor did not exit within %s and was killed (PID %d)", supervisorStopTimeout, result.PID))
+		}
+		
+		return nil
+	},
+}
+
+func supervisorConfigDir() (string, error) {
+	configDir, err := config.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	
+	if err := security.AllowRoot(configDir); err != nil {
+		return "", err
+	}
+	
+	if err := security.SecureCreateDir(configDir); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	
+	return configDir, nil
+}
+
+func runSupervisor(configDir string, d *daemon.Daemon) error {
+	if err := d.Daemonize(); err != nil {
+		d.NotifyFailure(err)
+		return fmt.Errorf("failed to start supervisor: %w", err)
+	}
+	defer d.RemovePID()
+	
+	log, err := logger.NewLogger(configDir)
+	if err != nil {
+		d.NotifyFailure(err)
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	defer log.Close()
+	
+	registryPath, err := registry.DefaultPath()
+	if err != nil {
+		d.NotifyFailure(err)
+		return err
+	}
+	
+	sup := supervisor.New(registryPath, log)
+	if err := sup.Sync(); err != nil {
+		log.Error(err.Error())
+		d.NotifyFailure(err)
+		return err
+	}
+	
+	log.Info(fmt.Sprintf("supervisor started (pid %d) hosting %d repositories", os.Getpid(), len(sup.Instances())))
+	fmt.Printf("GitSentry supervisor started, hosting %d repositories\n", len(sup.Instances()))
+	d.NotifyReady()
+	
+	stop := make(chan struct{})
+	go func() {
+		reason := d.WaitForShutdown(nil)
+		log.Info(fmt.Sprintf("%s, shutting down", reason))
+		close(stop)
+	}()
+	
+	sup.Run(stop)
+	log.Info("supervisor stopped")
+	
+	return nil
+}
+
+func supervisorRunning() bool {
+	configDir, err := config.UserConfigDir()
+	if err != nil {
+		return false
+	}
+	
+	return daemon.NewSupervisorDaemon(configDir).IsRunning()
+}
+
+func init() {
+	supervisorStartCmd.Flags().BoolVar(&supervisorForeground, "foreground", false, "Keep the supervisor attached to the terminal for debugging")
+	supervisorStartCmd.Flags().DurationVar(&supervisorReadyTimeout, "ready-timeout", daemon.DefaultTimeout, "How long to wait for the supervisor to become ready")
+	supervisorStopCmd.Flags().DurationVar(&supervisorStopTimeout, "timeout", daemon.DefaultTimeout, "How long to wait for the supervisor to exit")
+	supervisorStopCmd.Flags().BoolVar(&supervisorStopForce, "force", false, "Kill the supervisor if it does not exit within the timeout")
+	
+	supervisorCmd.AddCommand(supervisorStartCmd)
+	supervisorCmd.AddCommand(supervisorStopCmd)
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"gopkg.in/yaml.v3"
 	"gitsentry/internal/security"
@@ -13,6 +15,145 @@ type Config struct {
 	AutoSuggestCommits  bool  `yaml:"auto_suggest_commits"`
 	AutoSuggestPushes   bool  `yaml:"auto_suggest_pushes"`
 	CommitMessageFormat string `yaml:"commit_message_format"`
+	Monitor             Monitor `yaml:"monitor"`
+	Suggestions         Suggestions `yaml:"suggestions"`
+	Schedule            Schedule `yaml:"schedule"`
+	NaturalBreak        NaturalBreak `yaml:"natural_break"`
+	CommitLint          CommitLint `yaml:"commit_lint"`
+	CommitGate          CommitGate `yaml:"commit_gate"`
+	PushPolicy          PushPolicy `yaml:"push_policy"`
+	Secrets             Secrets `yaml:"secrets"`
+	RuleSettings        map[string]RuleSetting `yaml:"rule_settings,omitempty"`
+	Notifiers           []NotifierConfig `yaml:"notifiers,omitempty"`
+	Overrides           []Override `yaml:"overrides,omitempty"`
+}
+
+type Override struct {
+	Name         string                 `yaml:"name,omitempty"`
+	Paths        []string               `yaml:"paths,omitempty"`
+	Branches     []string               `yaml:"branches,omitempty"`
+	RuleSettings map[string]RuleSetting `yaml:"rule_settings,omitempty"`
+}
+
+type NotifierConfig struct {
+	Type    string   `yaml:"type"`
+	Command []string `yaml:"command,omitempty"`
+	URL     string   `yaml:"url,omitempty"`
+	Format  string   `yaml:"format,omitempty"`
+}
+
+type RuleSetting struct {
+	Enabled         *bool          `yaml:"enabled,omitempty"`
+	Urgent          *bool          `yaml:"urgent,omitempty"`
+	CooldownMinutes *int           `yaml:"cooldown_minutes,omitempty"`
+	Params          map[string]int `yaml:",inline"`
+}
+
+type Monitor struct {
+	DebounceMillis int `yaml:"debounce_ms"`
+	BurstThreshold int `yaml:"burst_threshold"`
+}
+
+func DefaultMonitor() Monitor {
+	return Monitor{
+		DebounceMillis: 300,
+		BurstThreshold: 200,
+	}
+}
+
+type Suggestions struct {
+	CooldownMinutes int `yaml:"cooldown_minutes"`
+	GrowthPercent   int `yaml:"growth_percent"`
+}
+
+func DefaultSuggestions() Suggestions {
+	return Suggestions{
+		CooldownMinutes: 15,
+		GrowthPercent:   50,
+	}
+}
+
+type NaturalBreak struct {
+	PauseSeconds int `yaml:"pause_seconds"`
+}
+
+func DefaultNaturalBreak() NaturalBreak {
+	return NaturalBreak{
+		PauseSeconds: 45,
+	}
+}
+
+type CommitLint struct {
+	Types             []string `yaml:"types,omitempty"`
+	Scopes            []string `yaml:"scopes,omitempty"`
+	RequireScope      bool     `yaml:"require_scope"`
+	MaxSubjectLength  int      `yaml:"max_subject_length"`
+	MaxBodyLineLength int      `yaml:"max_body_line_length"`
+	TicketPattern     string   `yaml:"ticket_pattern,omitempty"`
+}
+
+func DefaultCommitLint() CommitLint {
+	return CommitLint{
+		Types:             []string{"feat", "fix", "docs", "test", "chore", "refactor", "perf", "style", "build", "ci", "revert"},
+		MaxSubjectLength:  72,
+		MaxBodyLineLength: 72,
+	}
+}
+
+type CommitGate struct {
+	Mode          string `yaml:"mode"`
+	MaxFiles      int    `yaml:"max_files"`
+	MaxLines      int    `yaml:"max_lines"`
+	MaxFileSizeKB int    `yaml:"max_file_size_kb"`
+	AllowBinary   bool   `yaml:"allow_binary"`
+}
+
+func DefaultCommitGate() CommitGate {
+	return CommitGate{
+		Mode:          "warn",
+		MaxFileSizeKB: 1024,
+		AllowBinary:   true,
+	}
+}
+
+type PushPolicy struct {
+	Mode              string   `yaml:"mode"`
+	ProtectedBranches []string `yaml:"protected_branches,omitempty"`
+	SharedBranches    []string `yaml:"shared_branches,omitempty"`
+	AllowWIP          bool     `yaml:"allow_wip"`
+	RequireSignoff    bool     `yaml:"require_signoff"`
+	MaxCommits        int      `yaml:"max_commits"`
+}
+
+func DefaultPushPolicy() PushPolicy {
+	return PushPolicy{
+		Mode:           "warn",
+		SharedBranches: []string{"main", "master", "develop", "release/*"},
+	}
+}
+
+type Secrets struct {
+	Mode              string   `yaml:"mode"`
+	AllowPaths        []string `yaml:"allow_paths,omitempty"`
+	AllowFingerprints []string `yaml:"allow_fingerprints,omitempty"`
+}
+
+func DefaultSecrets() Secrets {
+	return Secrets{
+		Mode: "block",
+	}
+}
+
+type Schedule struct {
+	Timezone         string       `yaml:"timezone,omitempty"`
+	QuietHours       []QuietHours `yaml:"quiet_hours,omitempty"`
+	FocusIdleMinutes int          `yaml:"focus_idle_minutes"`
+}
+
+type QuietHours struct {
+	Days []string `yaml:"days,omitempty"`
+	From string   `yaml:"from"`
+	To   string   `yaml:"to"`
 }
 
 type Rules struct {
@@ -22,6 +163,19 @@ type Rules struct {
 	MaxUnpushedCommits     int `yaml:"max_unpushed_commits"`
 }
 
+func UserConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gitsentry"), nil
+	}
+	
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	
+	return filepath.Join(home, ".config", "gitsentry"), nil
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		Rules: Rules{
@@ -33,6 +187,13 @@ func DefaultConfig() *Config {
 		AutoSuggestCommits:  true,
 		AutoSuggestPushes:   true,
 		CommitMessageFormat: "conventional",
+		Monitor:             DefaultMonitor(),
+		Suggestions:         DefaultSuggestions(),
+		NaturalBreak:        DefaultNaturalBreak(),
+		CommitLint:          DefaultCommitLint(),
+		CommitGate:          DefaultCommitGate(),
+		PushPolicy:          DefaultPushPolicy(),
+		Secrets:             DefaultSecrets(),
 	}
 }
 
@@ -47,6 +208,13 @@ func TeamConfig() *Config {
 		AutoSuggestCommits:  true,
 		AutoSuggestPushes:   true,
 		CommitMessageFormat: "conventional",
+		Monitor:             DefaultMonitor(),
+		Suggestions:         DefaultSuggestions(),
+		NaturalBreak:        DefaultNaturalBreak(),
+		CommitLint:          DefaultCommitLint(),
+		CommitGate:          DefaultCommitGate(),
+		PushPolicy:          DefaultPushPolicy(),
+		Secrets:             DefaultSecrets(),
 	}
 }
 
@@ -61,6 +229,13 @@ func StrictConfig() *Config {
 		AutoSuggestCommits:  true,
 		AutoSuggestPushes:   true,
 		CommitMessageFormat: "conventional",
+		Monitor:             DefaultMonitor(),
+		Suggestions:         DefaultSuggestions(),
+		NaturalBreak:        DefaultNaturalBreak(),
+		CommitLint:          DefaultCommitLint(),
+		CommitGate:          DefaultCommitGate(),
+		PushPolicy:          DefaultPushPolicy(),
+		Secrets:             DefaultSecrets(),
 	}
 }
 
@@ -75,71 +250,91 @@ func RelaxedConfig() *Config {
 		AutoSuggestCommits:  true,
 		AutoSuggestPushes:   false,
 		CommitMessageFormat: "simple",
+		Monitor:             DefaultMonitor(),
+		Suggestions:         DefaultSuggestions(),
+		NaturalBreak:        DefaultNaturalBreak(),
+		CommitLint:          DefaultCommitLint(),
+		CommitGate:          DefaultCommitGate(),
+		PushPolicy:          DefaultPushPolicy(),
+		Secrets:             DefaultSecrets(),
 	}
 }
 
 func GetConfigByTemplate(template string) *Config {
-	switch template {
-	case "team":
-		return TeamConfig()
-	case "strict":
-		return StrictConfig()
-	case "relaxed":
-		return RelaxedConfig()
-	default:
-		return DefaultConfig()
+	if constructor, ok := builtinTemplates[template]; ok {
+		return constructor()
 	}
+	
+	return DefaultConfig()
 }
 
 func LoadWithTemplate(gitsentryDir, template string) (*Config, error) {
-	configPath := filepath.Join(gitsentryDir, "config.yaml")
+	configPath := filepath.Join(gitsentryDir, ConfigFile)
 	
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		config := GetConfigByTemplate(template)
-		if err := config.Save(gitsentryDir); err != nil {
+	if template != "" {
+		config, err := ResolveTemplate(template)
+		if err != nil {
 			return nil, err
 		}
-		return config, nil
+		
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			if err := SaveChanges(gitsentryDir, DefaultConfig(), config); err != nil {
+				return nil, err
+			}
+		}
 	}
 	
 	return Load(gitsentryDir)
 }
 
 func Load(gitsentryDir string) (*Config, error) {
-	configPath := filepath.Join(gitsentryDir, "config.yaml")
-	
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		config := DefaultConfig()
-		if err := config.Save(gitsentryDir); err != nil {
-			return nil, err
-		}
-		return config, nil
-	}
-	
-	data, err := security.SecureReadFile(configPath)
+	layered, err := LoadLayered(gitsentryDir)
 	if err != nil {
 		return nil, err
 	}
 	
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, err
-	}
+	return layered.Config, nil
+}
+
+func (c *Config) Save(gitsentryDir string) error {
+	configPath := filepath.Join(gitsentryDir, ConfigFile)
 	
-	if err := security.ValidateConfigStruct(&config); err != nil {
-		return nil, err
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
 	}
 	
-	return &config, nil
+	return security.SecureWriteFile(configPath, data)
 }
 
-func (c *Config) Save(gitsentryDir string) error {
-	configPath := filepath.Join(gitsentryDir, "config.yaml")
+func SaveChanges(gitsentryDir string, base, updated *Config) error {
+	configPath := filepath.Join(gitsentryDir, ConfigFile)
 	
-	data, err := yaml.Marshal(c)
+	local := make(map[string]interface{})
+	data, err := security.SecureReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(data, &local); err != nil {
+			return fmt.Errorf("invalid local config %s: %w", configPath, err)
+		}
+		if local == nil {
+			local = make(map[string]interface{})
+		}
+	}
+	
+	before := flatten("", toRaw(base))
+	for key, value := range flatten("", toRaw(updated)) {
+		if previous, ok := before[key]; !ok || !reflect.DeepEqual(previous, value) {
+			setPath(local, key, value)
+		}
+	}
+	
+	data, err = yaml.Marshal(local)
 	if err != nil {
 		return err
 	}
 	
 	return security.SecureWriteFile(configPath, data)
-}
\ No newline at end of file
+}
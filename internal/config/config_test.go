@@ -3,7 +3,12 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
+	"gitsentry/internal/security"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -86,4 +91,294 @@ func TestLoadWithTemplate(t *testing.T) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		t.Error("Config file should be created")
 	}
-}
\ No newline at end of file
+}
+
+func TestRuleSettingsRoundTrip(t *testing.T) {
+	tempDir := "test_rule_settings"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	content := `rules:
+  max_files_changed: 5
+  max_lines_changed: 100
+  max_minutes_since_commit: 30
+  max_unpushed_commits: 3
+rule_settings:
+  lines_changed:
+    enabled: false
+  files_changed:
+    threshold: 8
+`
+	os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte(content), 0644)
+	
+	config, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	
+	lines := config.RuleSettings["lines_changed"]
+	if lines.Enabled == nil || *lines.Enabled {
+		t.Error("lines_changed should be disabled")
+	}
+	
+	if config.RuleSettings["files_changed"].Params["threshold"] != 8 {
+		t.Errorf("Expected files_changed threshold 8, got %v", config.RuleSettings["files_changed"].Params)
+	}
+}
+
+func TestLayeredConfig(t *testing.T) {
+	tempDir := "test_layers"
+	os.MkdirAll(filepath.Join(tempDir, ".gitsentry"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "xdg", "gitsentry"), 0755)
+	defer os.RemoveAll(tempDir)
+	defer SetOverrides(nil)
+	
+	xdg, _ := filepath.Abs(filepath.Join(tempDir, "xdg"))
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("GITSENTRY_RULES_MAX_UNPUSHED_COMMITS", "7")
+	
+	os.WriteFile(filepath.Join(xdg, "gitsentry", "config.yaml"), []byte("rules:\n  max_files_changed: 11\n  max_lines_changed: 300\ncommit_message_format: simple\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, ".gitsentry.yaml"), []byte("rules:\n  max_lines_changed: 150\nrule_settings:\n  files_changed:\n    threshold: 4\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, ".gitsentry", "config.yaml"), []byte("rule_settings:\n  files_changed:\n    enabled: false\n"), 0644)
+	
+	if err := SetOverrides([]string{"auto_suggest_pushes=false"}); err != nil {
+		t.Fatalf("Failed to set overrides: %v", err)
+	}
+	
+	layered, err := LoadLayered(filepath.Join(tempDir, ".gitsentry"))
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %v", err)
+	}
+	
+	config := layered.Config
+	if config.Rules.MaxFilesChanged != 11 || config.CommitMessageFormat != "simple" {
+		t.Error("Global config should override defaults")
+	}
+	if config.Rules.MaxLinesChanged != 150 {
+		t.Errorf("Shared config should override global, got %d", config.Rules.MaxLinesChanged)
+	}
+	
+	files := config.RuleSettings["files_changed"]
+	if files.Params["threshold"] != 4 || files.Enabled == nil || *files.Enabled {
+		t.Errorf("Local and shared rule settings should merge, got %+v", files)
+	}
+	
+	if config.Rules.MaxUnpushedCommits != 7 {
+		t.Errorf("Environment should override files, got %d", config.Rules.MaxUnpushedCommits)
+	}
+	if config.AutoSuggestPushes {
+		t.Error("Flag override should win over everything else")
+	}
+	
+	expected := map[string]string{
+		"rules.max_files_changed":               LayerGlobal,
+		"rules.max_lines_changed":               LayerShared,
+		"rule_settings.files_changed.enabled":   LayerLocal,
+		"rule_settings.files_changed.threshold": LayerShared,
+		"rules.max_unpushed_commits":            LayerEnv,
+		"auto_suggest_pushes":                   LayerFlag,
+		"rules.max_minutes_since_commit":        LayerDefault,
+	}
+	
+	for _, setting := range layered.Explain() {
+		if layer, ok := expected[setting.Key]; ok && setting.Source.Layer != layer {
+			t.Errorf("%s should come from %s, got %s", setting.Key, layer, setting.Source)
+		}
+	}
+	
+	if err := security.ValidateFilePath(filepath.Join(xdg, "gitsentry", "other.yaml")); err == nil {
+		t.Error("Loading the global config should not widen the global path allowlist")
+	}
+	
+	if err := SetOverrides([]string{"no_such_key=1"}); err == nil {
+		t.Error("Unknown override key should be rejected")
+	}
+}
+
+func TestSharedConfigIgnoresNotifiers(t *testing.T) {
+	tempDir := "test_shared_notifiers"
+	os.MkdirAll(filepath.Join(tempDir, ".gitsentry"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	xdg, _ := filepath.Abs(filepath.Join(tempDir, "xdg"))
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	
+	os.WriteFile(filepath.Join(tempDir, ".gitsentry.yaml"), []byte("rules:\n  max_lines_changed: 150\nnotifiers:\n  - type: command\n    command: [\"sh\", \"-c\", \"touch pwned\"]\n  - type: webhook\n    url: https://example.com/collect\n"), 0644)
+	
+	layered, err := LoadLayered(filepath.Join(tempDir, ".gitsentry"))
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %v", err)
+	}
+	if len(layered.Config.Notifiers) != 0 {
+		t.Errorf("Shared config should not be able to configure notifiers, got %+v", layered.Config.Notifiers)
+	}
+	if layered.Config.Rules.MaxLinesChanged != 150 {
+		t.Error("Other shared settings should still apply")
+	}
+	
+	os.WriteFile(filepath.Join(tempDir, ".gitsentry", "config.yaml"), []byte("notifiers:\n  - type: webhook\n    url: https://hooks.example.com/mine\n"), 0644)
+	
+	layered, err = LoadLayered(filepath.Join(tempDir, ".gitsentry"))
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %v", err)
+	}
+	if len(layered.Config.Notifiers) != 1 || layered.Config.Notifiers[0].URL != "https://hooks.example.com/mine" {
+		t.Errorf("Local notifiers should be honoured, got %+v", layered.Config.Notifiers)
+	}
+}
+
+func TestSaveChangesKeepsLayers(t *testing.T) {
+	tempDir := "test_save_changes"
+	os.MkdirAll(filepath.Join(tempDir, ".gitsentry"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "xdg", "gitsentry"), 0755)
+	defer os.RemoveAll(tempDir)
+	defer SetOverrides(nil)
+	
+	xdg, _ := filepath.Abs(filepath.Join(tempDir, "xdg"))
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("GITSENTRY_RULES_MAX_UNPUSHED_COMMITS", "7")
+	gitsentryDir := filepath.Join(tempDir, ".gitsentry")
+	
+	os.WriteFile(filepath.Join(xdg, "gitsentry", "config.yaml"), []byte("commit_message_format: simple\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, ".gitsentry.yaml"), []byte("rules:\n  max_lines_changed: 150\n"), 0644)
+	os.WriteFile(filepath.Join(gitsentryDir, "config.yaml"), []byte("auto_suggest_pushes: false\n"), 0644)
+	SetOverrides([]string{"monitor.debounce_ms=900"})
+	
+	before, err := Load(gitsentryDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	
+	updated, _ := Load(gitsentryDir)
+	updated.Rules.MaxFilesChanged = 9
+	if err := SaveChanges(gitsentryDir, before, updated); err != nil {
+		t.Fatalf("Failed to save changes: %v", err)
+	}
+	
+	data, _ := os.ReadFile(filepath.Join(gitsentryDir, "config.yaml"))
+	var local map[string]interface{}
+	yaml.Unmarshal(data, &local)
+	
+	expected := map[string]interface{}{
+		"auto_suggest_pushes":     false,
+		"rules.max_files_changed": 9,
+	}
+	if !reflect.DeepEqual(flatten("", local), expected) {
+		t.Errorf("Only the changed key should be added to the local config, got:\n%s", data)
+	}
+	
+	os.WriteFile(filepath.Join(tempDir, ".gitsentry.yaml"), []byte("rules:\n  max_lines_changed: 80\n"), 0644)
+	reloaded, err := Load(gitsentryDir)
+	if err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+	if reloaded.Rules.MaxLinesChanged != 80 {
+		t.Errorf("Later shared config edits should still apply, got %d", reloaded.Rules.MaxLinesChanged)
+	}
+}
+
+func TestResolveTemplate(t *testing.T) {
+	tempDir := "test_templates"
+	os.MkdirAll(filepath.Join(tempDir, "xdg", "gitsentry", "templates"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	xdg, _ := filepath.Abs(filepath.Join(tempDir, "xdg"))
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	templatesDir := filepath.Join(xdg, "gitsentry", "templates")
+	
+	os.WriteFile(filepath.Join(templatesDir, "backend.yaml"), []byte("extends: strict\nrules:\n  max_lines_changed: 120\n"), 0644)
+	os.WriteFile(filepath.Join(templatesDir, "loop-a.yaml"), []byte("extends: loop-b\n"), 0644)
+	os.WriteFile(filepath.Join(templatesDir, "loop-b.yaml"), []byte("extends: loop-a\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "repo-template.yaml"), []byte("extends: backend\nauto_suggest_pushes: false\n"), 0644)
+	
+	backend, err := ResolveTemplate("backend")
+	if err != nil {
+		t.Fatalf("Failed to resolve user template: %v", err)
+	}
+	
+	strict := StrictConfig()
+	if backend.Rules.MaxLinesChanged != 120 || backend.Rules.MaxFilesChanged != strict.Rules.MaxFilesChanged {
+		t.Errorf("Template should inherit from strict and override only lines, got %+v", backend.Rules)
+	}
+	
+	fromFile, err := ResolveTemplate(filepath.Join(tempDir, "repo-template.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to resolve template file: %v", err)
+	}
+	if fromFile.AutoSuggestPushes || fromFile.Rules.MaxLinesChanged != 120 {
+		t.Errorf("File template should chain through backend, got %+v", fromFile)
+	}
+	
+	if _, err := ResolveTemplate("loop-a"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Expected inheritance cycle error, got %v", err)
+	}
+	
+	_, err = ResolveTemplate("nonexistent")
+	if err == nil {
+		t.Fatal("Unknown template should produce an error")
+	}
+	for _, name := range []string{"default", "team", "strict", "relaxed", "backend"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Error should list available template %s: %v", name, err)
+		}
+	}
+}
+
+func TestGateModesValidatedPerSection(t *testing.T) {
+	tempDir := "test_config_modes"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	abs, _ := filepath.Abs(tempDir)
+	t.Setenv("XDG_CONFIG_HOME", abs)
+	
+	cases := map[string]bool{
+		"commit_gate:\n  mode: block\npush_policy:\n  mode: warn\n":  true,
+		"commit_gate:\n  mode: bogus\npush_policy:\n  mode: warn\n":  false,
+		"commit_gate:\n  mode: block\npush_policy:\n  mode: bogus\n": false,
+		"push_policy:\n  max_commits: -1\n":                           false,
+	}
+	
+	for content, valid := range cases {
+		os.WriteFile(filepath.Join(tempDir, ConfigFile), []byte(content), 0644)
+		
+		_, err := Load(tempDir)
+		if valid && err != nil {
+			t.Errorf("Failed to load valid config %q: %v", content, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("Should reject config %q", content)
+		}
+	}
+}
+
+func TestTemplateExtendsStaysInDirectory(t *testing.T) {
+	tempDir := "test_template_scope"
+	os.MkdirAll(filepath.Join(tempDir, "templates", "nested"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "outside"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	xdg, _ := filepath.Abs(filepath.Join(tempDir, "xdg"))
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	
+	outside, _ := filepath.Abs(filepath.Join(tempDir, "outside", "base.yaml"))
+	os.WriteFile(outside, []byte("rules:\n  max_files_changed: 9\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "templates", "nested", "base.yaml"), []byte("extends: strict\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "templates", "ok.yaml"), []byte("extends: nested/base.yaml\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "templates", "absolute.yaml"), []byte("extends: "+outside+"\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "templates", "relative.yaml"), []byte("extends: ../outside/base.yaml\n"), 0644)
+	
+	if _, err := ResolveTemplate(filepath.Join(tempDir, "templates", "ok.yaml")); err != nil {
+		t.Errorf("Extending a template below the same directory should work: %v", err)
+	}
+	
+	for _, name := range []string{"absolute.yaml", "relative.yaml"} {
+		if _, err := ResolveTemplate(filepath.Join(tempDir, "templates", name)); err == nil {
+			t.Errorf("%s should not be able to extend a file outside its directory", name)
+		}
+	}
+	
+	if err := security.ValidateFilePath(outside); err == nil {
+		t.Error("Resolving templates should not widen the global path allowlist")
+	}
+}
@@ -0,0 +1,397 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+	"gitsentry/internal/security"
+)
+
+const (
+	LayerDefault = "default"
+	LayerGlobal  = "global"
+	LayerShared  = "shared"
+	LayerLocal   = "local"
+	LayerEnv     = "env"
+	LayerFlag    = "flag"
+	
+	ConfigFile       = "config.yaml"
+	SharedConfigFile = ".gitsentry.yaml"
+	EnvPrefix        = "GITSENTRY_"
+)
+
+var (
+	overridesMu sync.RWMutex
+	overrides   []override
+	
+	localOnlyKeys = []string{"notifiers"}
+)
+
+type override struct {
+	key   string
+	value string
+}
+
+type Source struct {
+	Layer  string
+	Origin string
+}
+
+func (s Source) String() string {
+	if s.Origin == "" {
+		return s.Layer
+	}
+	return fmt.Sprintf("%s (%s)", s.Layer, s.Origin)
+}
+
+type Setting struct {
+	Key    string
+	Value  string
+	Source Source
+}
+
+type Layered struct {
+	Config  *Config
+	raw     map[string]interface{}
+	sources map[string]Source
+}
+
+func GlobalConfigPath() (string, error) {
+	dir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	
+	return filepath.Join(dir, ConfigFile), nil
+}
+
+func SharedConfigPath(gitsentryDir string) string {
+	return filepath.Join(filepath.Dir(gitsentryDir), SharedConfigFile)
+}
+
+func SourcePaths(gitsentryDir string) []string {
+	var paths []string
+	if path, err := GlobalConfigPath(); err == nil {
+		paths = append(paths, path)
+	}
+	
+	return append(paths, SharedConfigPath(gitsentryDir), filepath.Join(gitsentryDir, ConfigFile))
+}
+
+func SetOverrides(assignments []string) error {
+	known := knownKeys()
+	
+	var parsed []override
+	for _, assignment := range assignments {
+		key, value, found := strings.Cut(assignment, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return fmt.Errorf("invalid override %q, expected key=value", assignment)
+		}
+		
+		if !known[key] && !isRuleSettingKey(key) {
+			return fmt.Errorf("unknown config key: %s", key)
+		}
+		
+		parsed = append(parsed, override{key: key, value: value})
+	}
+	
+	overridesMu.Lock()
+	overrides = parsed
+	overridesMu.Unlock()
+	
+	return nil
+}
+
+func LoadLayered(gitsentryDir string) (*Layered, error) {
+	l := &Layered{
+		raw:     make(map[string]interface{}),
+		sources: make(map[string]Source),
+	}
+	
+	if path, err := GlobalConfigPath(); err == nil {
+		if err := l.applyFileWithin(LayerGlobal, filepath.Dir(path), path); err != nil {
+			return nil, err
+		}
+	}
+	
+	if err := l.applyFile(LayerShared, SharedConfigPath(gitsentryDir)); err != nil {
+		return nil, err
+	}
+	
+	if err := l.applyFile(LayerLocal, filepath.Join(gitsentryDir, ConfigFile)); err != nil {
+		return nil, err
+	}
+	
+	l.applyEnv()
+	l.applyOverrides()
+	
+	data, err := yaml.Marshal(l.raw)
+	if err != nil {
+		return nil, err
+	}
+	
+	config := DefaultConfig()
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	
+	if err := security.ValidateConfigStruct(config); err != nil {
+		return nil, err
+	}
+	
+	l.Config = config
+	return l, nil
+}
+
+func (l *Layered) Explain() []Setting {
+	effective := flatten("", toRaw(l.Config))
+	
+	keys := make([]string, 0, len(effective))
+	for key := range effective {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		source, ok := l.sources[key]
+		if !ok {
+			source = Source{Layer: LayerDefault}
+		}
+		
+		settings = append(settings, Setting{
+			Key:    key,
+			Value:  formatValue(effective[key]),
+			Source: source,
+		})
+	}
+	
+	return settings
+}
+
+func (l *Layered) applyFile(layer, path string) error {
+	return l.applyData(layer, path, security.SecureReadFile)
+}
+
+func (l *Layered) applyFileWithin(layer, root, path string) error {
+	return l.applyData(layer, path, func(path string) ([]byte, error) {
+		return security.SecureReadFileWithin(root, path)
+	})
+}
+
+func (l *Layered) applyData(layer, path string, read func(string) ([]byte, error)) error {
+	data, err := read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s config %s: %w", layer, path, err)
+	}
+	
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid %s config %s: %w", layer, path, err)
+	}
+	
+	if layer == LayerShared {
+		for _, key := range localOnlyKeys {
+			if _, ok := raw[key]; ok {
+				delete(raw, key)
+				fmt.Fprintf(os.Stderr, "GitSentry: ignoring %s in shared config %s, set it in the global or local config instead\n", key, path)
+			}
+		}
+	}
+	
+	l.merge(raw, Source{Layer: layer, Origin: path})
+	return nil
+}
+
+func (l *Layered) applyEnv() {
+	envKeys := make(map[string]string)
+	for key := range knownKeys() {
+		envKeys[EnvName(key)] = key
+	}
+	
+	names := make([]string, 0)
+	for _, entry := range os.Environ() {
+		name, _, _ := strings.Cut(entry, "=")
+		if _, ok := envKeys[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	
+	for _, name := range names {
+		raw := make(map[string]interface{})
+		setPath(raw, envKeys[name], parseScalar(os.Getenv(name)))
+		l.merge(raw, Source{Layer: LayerEnv, Origin: name})
+	}
+}
+
+func (l *Layered) applyOverrides() {
+	overridesMu.RLock()
+	defer overridesMu.RUnlock()
+	
+	for _, o := range overrides {
+		raw := make(map[string]interface{})
+		setPath(raw, o.key, parseScalar(o.value))
+		l.merge(raw, Source{Layer: LayerFlag, Origin: "--set " + o.key})
+	}
+}
+
+func (l *Layered) merge(raw map[string]interface{}, source Source) {
+	mergeMaps(l.raw, raw)
+	
+	for key := range flatten("", raw) {
+		for existing := range l.sources {
+			if strings.HasPrefix(existing, key+".") {
+				delete(l.sources, existing)
+			}
+		}
+		l.sources[key] = source
+	}
+}
+
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func knownKeys() map[string]bool {
+	keys := make(map[string]bool)
+	collectKeys("", reflect.TypeOf(Config{}), keys)
+	
+	return keys
+}
+
+func collectKeys(prefix string, t reflect.Type, keys map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		
+		if field.Type.Kind() == reflect.Struct {
+			collectKeys(name, field.Type, keys)
+			continue
+		}
+		
+		keys[name] = true
+	}
+}
+
+func isRuleSettingKey(key string) bool {
+	parts := strings.Split(key, ".")
+	return len(parts) == 3 && parts[0] == "rule_settings" && parts[1] != "" && parts[2] != ""
+}
+
+func toRaw(config *Config) map[string]interface{} {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil
+	}
+	
+	var raw map[string]interface{}
+	yaml.Unmarshal(data, &raw)
+	
+	return raw
+}
+
+func flatten(prefix string, raw map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	
+	for key, value := range raw {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			for k, v := range flatten(fullKey, nested) {
+				flat[k] = v
+			}
+			continue
+		}
+		
+		flat[fullKey] = value
+	}
+	
+	return flat
+}
+
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		
+		if srcIsMap {
+			copied := make(map[string]interface{})
+			mergeMaps(copied, srcMap)
+			dst[key] = copied
+			continue
+		}
+		
+		dst[key] = value
+	}
+}
+
+func setPath(raw map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	
+	current := raw
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[part] = next
+		}
+		current = next
+	}
+	
+	current[parts[len(parts)-1]] = value
+}
+
+func parseScalar(value string) interface{} {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil || parsed == nil {
+		return value
+	}
+	
+	return parsed
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"gitsentry/internal/security"
+)
+
+const (
+	TemplatesDirName = "templates"
+	ExtendsKey       = "extends"
+	
+	maxTemplateDepth = 16
+)
+
+var builtinTemplates = map[string]func() *Config{
+	"default": DefaultConfig,
+	"team":    TeamConfig,
+	"strict":  StrictConfig,
+	"relaxed": RelaxedConfig,
+}
+
+func TemplatesDir() (string, error) {
+	dir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	
+	return filepath.Join(dir, TemplatesDirName), nil
+}
+
+func AvailableTemplates() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	
+	if dir, err := TemplatesDir(); err == nil {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			name := entry.Name()
+			ext := filepath.Ext(name)
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			
+			name = strings.TrimSuffix(name, ext)
+			if _, builtin := builtinTemplates[name]; !builtin {
+				names = append(names, name)
+			}
+		}
+	}
+	
+	sort.Strings(names)
+	return names
+}
+
+func ResolveTemplate(name string) (*Config, error) {
+	raw, err := resolveTemplate(name, ".", nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	
+	config := DefaultConfig()
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid template %q: %w", name, err)
+	}
+	
+	if err := security.ValidateConfigStruct(config); err != nil {
+		return nil, fmt.Errorf("invalid template %q: %w", name, err)
+	}
+	
+	return config, nil
+}
+
+func resolveTemplate(name, baseDir string, chain []string) (map[string]interface{}, error) {
+	if len(chain) >= maxTemplateDepth {
+		return nil, fmt.Errorf("template inheritance too deep: %s", strings.Join(chain, " -> "))
+	}
+	
+	if constructor, ok := builtinTemplates[name]; ok {
+		return toRaw(constructor()), nil
+	}
+	
+	path, root, err := findTemplateFile(name, baseDir, len(chain) > 0)
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, visited := range chain {
+		if visited == path {
+			return nil, fmt.Errorf("template inheritance cycle: %s -> %s", strings.Join(chain, " -> "), path)
+		}
+	}
+	chain = append(chain, path)
+	
+	data, err := security.SecureReadFileWithin(root, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+	
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", path, err)
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+	
+	base := toRaw(DefaultConfig())
+	if parent, ok := raw[ExtendsKey]; ok {
+		parentName, isString := parent.(string)
+		if !isString || parentName == "" {
+			return nil, fmt.Errorf("template %s: %s must be a template name or path", path, ExtendsKey)
+		}
+		
+		base, err = resolveTemplate(parentName, filepath.Dir(path), chain)
+		if err != nil {
+			return nil, err
+		}
+		delete(raw, ExtendsKey)
+	}
+	
+	mergeMaps(base, raw)
+	return base, nil
+}
+
+func findTemplateFile(name, baseDir string, nested bool) (string, string, error) {
+	if isTemplatePath(name) {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		
+		root := filepath.Dir(path)
+		if nested {
+			root = baseDir
+		}
+		
+		if _, err := os.Stat(path); err != nil {
+			return "", "", fmt.Errorf("template file not found: %s", path)
+		}
+		return path, root, nil
+	}
+	
+	dir, err := TemplatesDir()
+	if err == nil {
+		for _, ext := range []string{".yaml", ".yml"} {
+			path := filepath.Join(dir, name+ext)
+			if _, err := os.Stat(path); err == nil {
+				return path, dir, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", "", fmt.Errorf("failed to read template %s: %w", path, err)
+			}
+		}
+	}
+	
+	return "", "", fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(AvailableTemplates(), ", "))
+}
+
+func isTemplatePath(name string) bool {
+	ext := filepath.Ext(name)
+	return strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) || ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,203 @@
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	CommandStatus       = "status"
+	CommandReloadConfig = "reload-config"
+	CommandSnooze       = "snooze"
+	CommandFlush        = "flush"
+	CommandShutdown     = "shutdown"
+)
+
+const (
+	socketName     = "gitsentry.sock"
+	requestTimeout = 5 * time.Second
+)
+
+var ErrUnavailable = errors.New("daemon is not reachable")
+
+type Request struct {
+	Command  string `json:"command"`
+	Duration string `json:"duration,omitempty"`
+}
+
+type Response struct {
+	OK    bool            `json:"ok"`
+	Error string          `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+type Handler func(Request) (interface{}, error)
+
+func SocketPath(gitsentryDir string) string {
+	return filepath.Join(gitsentryDir, socketName)
+}
+
+type Server struct {
+	path     string
+	listener net.Listener
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	wg       sync.WaitGroup
+}
+
+func NewServer(path string) *Server {
+	return &Server{
+		path:     path,
+		handlers: make(map[string]Handler),
+	}
+}
+
+func (s *Server) Handle(command string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.handlers[command] = handler
+}
+
+func (s *Server) Start() error {
+	if _, err := os.Stat(s.path); err == nil {
+		if conn, err := net.DialTimeout("unix", s.path, time.Second); err == nil {
+			conn.Close()
+			return fmt.Errorf("control socket %s is already in use", s.path)
+		}
+		
+		if err := os.Remove(s.path); err != nil {
+			return fmt.Errorf("failed to remove stale control socket: %w", err)
+		}
+	}
+	
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	
+	if err := os.Chmod(s.path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to secure control socket: %w", err)
+	}
+	
+	s.listener = listener
+	
+	s.wg.Add(1)
+	go s.serve()
+	
+	return nil
+}
+
+func (s *Server) Close() error {
+	if s.listener == nil {
+		return nil
+	}
+	
+	err := s.listener.Close()
+	s.wg.Wait()
+	os.Remove(s.path)
+	
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn)
+		}()
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(requestTimeout))
+	
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		json.NewEncoder(conn).Encode(Response{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	
+	json.NewEncoder(conn).Encode(s.dispatch(req))
+}
+
+func (s *Server) dispatch(req Request) Response {
+	s.mu.RLock()
+	handler, ok := s.handlers[req.Command]
+	s.mu.RUnlock()
+	
+	if !ok {
+		return Response{Error: fmt.Sprintf("unknown command: %s", req.Command)}
+	}
+	
+	result, err := handler(req)
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+	
+	resp := Response{OK: true}
+	if result != nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return Response{Error: fmt.Sprintf("failed to encode response: %v", err)}
+		}
+		resp.Data = data
+	}
+	
+	return resp
+}
+
+type Client struct {
+	path    string
+	timeout time.Duration
+}
+
+func NewClient(path string) *Client {
+	return &Client{path: path, timeout: requestTimeout}
+}
+
+func (c *Client) Call(req Request, out interface{}) error {
+	conn, err := net.DialTimeout("unix", c.path, c.timeout)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.timeout))
+	
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if !resp.OK {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	
+	if out != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	
+	return nil
+}
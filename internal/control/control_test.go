@@ -0,0 +1,78 @@
+package control
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestServerClientRoundTrip(t *testing.T) {
+	tempDir := "test_control"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	server := NewServer(SocketPath(tempDir))
+	server.Handle(CommandStatus, func(req Request) (interface{}, error) {
+		return map[string]int{"files_changed": 3}, nil
+	})
+	server.Handle(CommandSnooze, func(req Request) (interface{}, error) {
+		if req.Duration == "" {
+			return nil, errors.New("duration required")
+		}
+		return nil, nil
+	})
+	
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Close()
+	
+	client := NewClient(SocketPath(tempDir))
+	
+	var status map[string]int
+	if err := client.Call(Request{Command: CommandStatus}, &status); err != nil {
+		t.Fatalf("Status call failed: %v", err)
+	}
+	if status["files_changed"] != 3 {
+		t.Errorf("Expected files_changed 3, got %v", status)
+	}
+	
+	if err := client.Call(Request{Command: CommandSnooze}, nil); err == nil {
+		t.Error("Expected handler error to be returned")
+	}
+	
+	if err := client.Call(Request{Command: "bogus"}, nil); err == nil {
+		t.Error("Expected unknown command error")
+	}
+	
+	if err := NewServer(SocketPath(tempDir)).Start(); err == nil {
+		t.Error("Second server on the same socket should fail")
+	}
+}
+
+func TestClientUnavailable(t *testing.T) {
+	client := NewClient("test_control_missing/gitsentry.sock")
+	
+	err := client.Call(Request{Command: CommandStatus}, nil)
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestServerReplacesStaleSocket(t *testing.T) {
+	tempDir := "test_control_stale"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	os.WriteFile(SocketPath(tempDir), []byte{}, 0600)
+	
+	server := NewServer(SocketPath(tempDir))
+	if err := server.Start(); err != nil {
+		t.Fatalf("Server should replace stale socket: %v", err)
+	}
+	server.Close()
+	
+	if _, err := os.Stat(SocketPath(tempDir)); !os.IsNotExist(err) {
+		t.Error("Socket should be removed on close")
+	}
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/control"
+)
+
+const (
+	SnoozeUntilCommit = "until-commit"
+	SnoozeOff         = "off"
+)
+
+type SnoozeStatus struct {
+	Until       time.Time `json:"until,omitempty"`
+	UntilCommit bool      `json:"until_commit,omitempty"`
+}
+
+func (s SnoozeStatus) Active() bool {
+	return s.UntilCommit || !s.Until.IsZero()
+}
+
+func (s SnoozeStatus) String() string {
+	switch {
+	case s.UntilCommit:
+		return "until next commit"
+	case !s.Until.IsZero():
+		return "until " + s.Until.Format("2006-01-02 15:04:05")
+	default:
+		return "off"
+	}
+}
+
+func (gs *GitSentry) startControlServer() error {
+	server := control.NewServer(control.SocketPath(filepath.Join(gs.repoPath, ".gitsentry")))
+	
+	server.Handle(control.CommandStatus, func(req control.Request) (interface{}, error) {
+		return gs.GetStatus()
+	})
+	server.Handle(control.CommandReloadConfig, func(req control.Request) (interface{}, error) {
+		return nil, gs.ReloadConfig()
+	})
+	server.Handle(control.CommandSnooze, func(req control.Request) (interface{}, error) {
+		return gs.Snooze(req.Duration)
+	})
+	server.Handle(control.CommandFlush, func(req control.Request) (interface{}, error) {
+		return nil, gs.Flush()
+	})
+	server.Handle(control.CommandShutdown, func(req control.Request) (interface{}, error) {
+		gs.RequestShutdown()
+		return nil, nil
+	})
+	
+	if err := server.Start(); err != nil {
+		return err
+	}
+	
+	gs.control = server
+	return nil
+}
+
+func (gs *GitSentry) ReloadConfig() error {
+	cfg, err := config.Load(filepath.Join(gs.repoPath, ".gitsentry"))
+	if err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+	
+	return gs.applyConfig(cfg)
+}
+
+func (gs *GitSentry) onConfigChange() {
+	if !gs.isRunning.Load() {
+		return
+	}
+	
+	previous := gs.currentConfig()
+	cfg, err := config.Load(filepath.Join(gs.repoPath, ".gitsentry"))
+	if err == nil && reflect.DeepEqual(cfg, previous) {
+		return
+	}
+	if err == nil {
+		err = gs.applyConfig(cfg)
+	}
+	
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "GitSentry: config change rejected, keeping previous configuration: %v\n", err)
+		return
+	}
+	
+	fmt.Fprintln(os.Stderr, "GitSentry: configuration reloaded")
+	if previous != nil && cfg.Monitor != previous.Monitor {
+		fmt.Fprintln(os.Stderr, "GitSentry: monitor settings take effect after a restart")
+	}
+}
+
+func (gs *GitSentry) Snooze(spec string) (SnoozeStatus, error) {
+	if err := gs.ensureState(); err != nil {
+		return SnoozeStatus{}, err
+	}
+	
+	switch spec {
+	case SnoozeUntilCommit:
+		gs.state.SnoozeUntilCommit()
+	case SnoozeOff:
+		gs.state.ClearSnooze()
+	default:
+		duration, err := time.ParseDuration(spec)
+		if err != nil || duration <= 0 {
+			return SnoozeStatus{}, fmt.Errorf("invalid snooze duration: %q", spec)
+		}
+		gs.state.Snooze(time.Now().Add(duration))
+	}
+	
+	if err := gs.state.Save(filepath.Join(gs.repoPath, ".gitsentry")); err != nil {
+		return SnoozeStatus{}, fmt.Errorf("failed to save state: %w", err)
+	}
+	
+	return gs.snoozeStatus(), nil
+}
+
+func (gs *GitSentry) snoozeStatus() SnoozeStatus {
+	if gs.state == nil {
+		return SnoozeStatus{}
+	}
+	
+	until, untilCommit := gs.state.GetSnooze()
+	if untilCommit {
+		return SnoozeStatus{UntilCommit: true}
+	}
+	if time.Now().Before(until) {
+		return SnoozeStatus{Until: until}
+	}
+	
+	return SnoozeStatus{}
+}
+
+func (gs *GitSentry) Flush() error {
+	if gs.state == nil {
+		return nil
+	}
+	
+	gs.refreshWorkingTree()
+	
+	return gs.state.Save(filepath.Join(gs.repoPath, ".gitsentry"))
+}
+
+func (gs *GitSentry) currentConfig() *config.Config {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	
+	return gs.config
+}
+
+func (gs *GitSentry) isSnoozed() bool {
+	return gs.state != nil && gs.state.IsSnoozed(time.Now())
+}
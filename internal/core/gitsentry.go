@@ -4,12 +4,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"gitsentry/internal/config"
+	"gitsentry/internal/control"
 	"gitsentry/internal/daemon"
+	"gitsentry/internal/gate"
 	"gitsentry/internal/git"
+	"gitsentry/internal/logger"
+	"gitsentry/internal/message"
 	"gitsentry/internal/monitor"
+	"gitsentry/internal/notify"
+	"gitsentry/internal/rules"
+	"gitsentry/internal/schedule"
+	"gitsentry/internal/secrets"
 	"gitsentry/internal/security"
 	"gitsentry/internal/state"
 )
@@ -20,7 +30,29 @@ type GitSentry struct {
 	state       *state.State
 	gitRepo     *git.Repository
 	monitor     *monitor.FileMonitor
-	isRunning   bool
+	refMonitor  *monitor.RefMonitor
+	cfgMonitor  *monitor.ConfigMonitor
+	control     *control.Server
+	ruleSet     *rules.Scoped
+	secrets     *secrets.Tracker
+	notifier    notify.Notifier
+	sched       *schedule.Schedule
+	activity    *monitor.Activity
+	breakTimer  *time.Timer
+	suggestMu   sync.Mutex
+	refsMu      sync.Mutex
+	mu          sync.RWMutex
+	done        chan struct{}
+	shutdown    chan struct{}
+	stopOnce    sync.Once
+	options     Options
+	isRunning   atomic.Bool
+}
+
+type Options struct {
+	WatchBudget       *monitor.Budget
+	ExternalScheduler bool
+	OnShutdown        func()
 }
 
 type Status struct {
@@ -33,11 +65,19 @@ type Status struct {
 	LastCommit      string
 	LastPush        string
 	UnpushedCommits int
+	UnwatchedDirs   int
+	Snooze          SnoozeStatus
 }
 
 func NewGitSentry(repoPath string) *GitSentry {
+	return NewGitSentryWithOptions(repoPath, Options{})
+}
+
+func NewGitSentryWithOptions(repoPath string, options Options) *GitSentry {
 	return &GitSentry{
 		repoPath: repoPath,
+		shutdown: make(chan struct{}),
+		options:  options,
 	}
 }
 
@@ -46,6 +86,12 @@ func (gs *GitSentry) Initialize() error {
 }
 
 func (gs *GitSentry) InitializeWithTemplate(template string) error {
+	if template != "" {
+		if _, err := config.ResolveTemplate(template); err != nil {
+			return err
+		}
+	}
+	
 	gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
 	if err := security.SecureCreateDir(gitsentryDir); err != nil {
 		return fmt.Errorf("failed to create .gitsentry directory: %w", err)
@@ -92,22 +138,43 @@ func (gs *GitSentry) StartDaemon() error {
 	d := daemon.NewDaemon(gs.repoPath)
 	
 	if err := d.Daemonize(); err != nil {
+		d.NotifyFailure(err)
 		return fmt.Errorf("failed to start daemon: %w", err)
 	}
+	defer d.RemovePID()
+	
+	log, err := logger.NewLogger(filepath.Join(gs.repoPath, ".gitsentry"))
+	if err != nil {
+		d.NotifyFailure(err)
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+	defer log.Close()
 	
 	if err := gs.Start(); err != nil {
-		d.RemovePID()
+		log.Error(fmt.Sprintf("failed to start monitoring: %v", err))
+		d.NotifyFailure(err)
 		return fmt.Errorf("failed to start monitoring: %w", err)
 	}
 	
+	log.Info(fmt.Sprintf("daemon started (pid %d) monitoring %s", os.Getpid(), gs.repoPath))
 	fmt.Println("GitSentry daemon started successfully")
 	fmt.Printf("Monitoring: %s\n", gs.repoPath)
+	d.NotifyReady()
+	
+	reason := d.WaitForShutdown(gs.ShutdownRequested())
+	log.Info(fmt.Sprintf("%s, shutting down", reason))
 	
-	select {}
+	if err := gs.Stop(); err != nil {
+		log.Error(fmt.Sprintf("failed to stop cleanly: %v", err))
+		return err
+	}
+	
+	log.Info("daemon stopped")
+	return nil
 }
 
 func (gs *GitSentry) Start() error {
-	if gs.isRunning {
+	if gs.isRunning.Load() {
 		return fmt.Errorf("GitSentry is already running")
 	}
 	
@@ -120,6 +187,10 @@ func (gs *GitSentry) Start() error {
 		gs.config = cfg
 	}
 	
+	if err := gs.applyConfig(gs.config); err != nil {
+		return err
+	}
+	
 	if gs.state == nil {
 		gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
 		st, err := state.Load(gitsentryDir)
@@ -136,38 +207,135 @@ func (gs *GitSentry) Start() error {
 		}
 	}
 	
-	monitor, err := monitor.NewFileMonitor(gs.repoPath, gs.onFileChange)
+	fileMonitor, err := monitor.NewFileMonitor(gs.repoPath, gs.monitorOptions(), gs.onFileChange)
 	if err != nil {
 		return fmt.Errorf("failed to start file monitor: %w", err)
 	}
-	gs.monitor = monitor
+	gs.monitor = fileMonitor
+	
+	if skipped := fileMonitor.UnwatchedDirs(); skipped > 0 {
+		fmt.Fprintf(os.Stderr, "GitSentry: file watch budget exhausted, %d directories are not watched\n", skipped)
+	}
 	
-	gs.isRunning = true
+	if gs.gitRepo != nil {
+		refMonitor, err := monitor.NewRefMonitor(gs.gitRepo.GitDir(), gs.options.WatchBudget, gs.checkRefs)
+		if err == nil {
+			gs.refMonitor = refMonitor
+		}
+	}
 	
-	go gs.monitorLoop()
+	cfgMonitor, err := monitor.NewConfigMonitor(config.SourcePaths(filepath.Join(gs.repoPath, ".gitsentry")), gs.options.WatchBudget, gs.onConfigChange)
+	if err == nil {
+		gs.cfgMonitor = cfgMonitor
+	}
+	
+	gs.checkRefs()
+	gs.refreshWorkingTree()
+	
+	if err := gs.startControlServer(); err != nil {
+		gs.stopMonitors()
+		return err
+	}
+	
+	gs.done = make(chan struct{})
+	gs.isRunning.Store(true)
+	
+	if !gs.options.ExternalScheduler {
+		go gs.monitorLoop()
+	}
 	
 	return nil
 }
 
 func (gs *GitSentry) Stop() error {
-	if !gs.isRunning {
+	if !gs.isRunning.CompareAndSwap(true, false) {
 		return nil
 	}
+	close(gs.done)
+	
+	gs.mu.Lock()
+	if gs.breakTimer != nil {
+		gs.breakTimer.Stop()
+		gs.breakTimer = nil
+	}
+	gs.mu.Unlock()
+	
+	gs.stopMonitors()
+	
+	if gs.control != nil {
+		gs.control.Close()
+	}
 	
-	gs.isRunning = false
+	if gs.state != nil {
+		if err := gs.state.Save(filepath.Join(gs.repoPath, ".gitsentry")); err != nil {
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+	}
 	
+	return nil
+}
+
+func (gs *GitSentry) stopMonitors() {
 	if gs.monitor != nil {
 		gs.monitor.Stop()
 	}
 	
+	if gs.refMonitor != nil {
+		gs.refMonitor.Stop()
+	}
+	
+	if gs.cfgMonitor != nil {
+		gs.cfgMonitor.Stop()
+	}
+}
+
+func (gs *GitSentry) RequestShutdown() {
+	gs.stopOnce.Do(func() {
+		close(gs.shutdown)
+	})
+	
+	if gs.options.OnShutdown != nil {
+		gs.options.OnShutdown()
+	}
+}
+
+func (gs *GitSentry) ShutdownRequested() <-chan struct{} {
+	return gs.shutdown
+}
+
+func (gs *GitSentry) ensureState() error {
+	if gs.state != nil {
+		return nil
+	}
+	
+	st, err := state.Load(filepath.Join(gs.repoPath, ".gitsentry"))
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	gs.state = st
+	
 	return nil
 }
 
 func (gs *GitSentry) GetStatus() (*Status, error) {
+	if err := gs.ensureState(); err != nil {
+		return nil, err
+	}
+	
+	if gs.gitRepo == nil {
+		if gitRepo, err := git.NewRepository(gs.repoPath); err == nil {
+			gs.gitRepo = gitRepo
+		}
+	}
+	
 	status := &Status{
 		RepoPath:     gs.repoPath,
 		IsGitRepo:    gs.gitRepo != nil,
-		IsMonitoring: gs.isRunning,
+		IsMonitoring: gs.isRunning.Load(),
+	}
+	
+	if status.IsMonitoring && gs.monitor != nil {
+		status.UnwatchedDirs = gs.monitor.UnwatchedDirs()
 	}
 	
 	if gs.state != nil {
@@ -175,6 +343,7 @@ func (gs *GitSentry) GetStatus() (*Status, error) {
 		status.FilesChanged = filesChanged
 		status.LinesAdded = linesAdded
 		status.LinesRemoved = linesRemoved
+		status.Snooze = gs.snoozeStatus()
 		
 		if !lastCommit.IsZero() {
 			status.LastCommit = lastCommit.Format("2006-01-02 15:04:05")
@@ -199,109 +368,402 @@ func (gs *GitSentry) GetStatus() (*Status, error) {
 	return status, nil
 }
 
-func (gs *GitSentry) SaveConfig(config *config.Config) error {
+func (gs *GitSentry) SaveConfig(cfg *config.Config) error {
 	gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
 	
-	if err := config.Save(gitsentryDir); err != nil {
+	current, err := config.Load(gitsentryDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	
+	if err := config.SaveChanges(gitsentryDir, current, cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 	
-	gs.config = config
+	gs.mu.Lock()
+	gs.config = cfg
+	gs.mu.Unlock()
 	return nil
 }
 
 func (gs *GitSentry) GetConfig() (*config.Config, error) {
-	if gs.config == nil {
-		gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
-		cfg, err := config.Load(gitsentryDir)
+	if cfg := gs.currentConfig(); cfg != nil {
+		return cfg, nil
+	}
+	
+	gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
+	cfg, err := config.Load(gitsentryDir)
+	if err != nil {
+		return nil, err
+	}
+	
+	gs.mu.Lock()
+	gs.config = cfg
+	gs.mu.Unlock()
+	
+	return cfg, nil
+}
+
+func (gs *GitSentry) DraftCommitMessage() (string, error) {
+	cfg, err := gs.GetConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	
+	repo, err := gs.repository()
+	if err != nil {
+		return "", err
+	}
+	
+	patches, err := repo.Patch()
+	if err != nil {
+		return "", fmt.Errorf("failed to read working tree diff: %w", err)
+	}
+	
+	msg, err := message.Draft(patches, cfg.CommitLint.MaxSubjectLength)
+	if err != nil {
+		return "", err
+	}
+	
+	return msg.Format(cfg.CommitMessageFormat, cfg.CommitLint.MaxBodyLineLength), nil
+}
+
+func (gs *GitSentry) CheckCommitGate() (*gate.Report, error) {
+	cfg, err := gs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	
+	if cfg.CommitGate.Mode == gate.ModeOff {
+		return &gate.Report{Mode: gate.ModeOff}, nil
+	}
+	
+	repo, err := gs.repository()
+	if err != nil {
+		return nil, err
+	}
+	
+	stat, err := repo.StagedDiffStat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read staged changes: %w", err)
+	}
+	
+	return gate.CheckCommit(stat, repo.StagedSize, cfg.CommitGate.Mode, gate.CommitLimits(cfg)), nil
+}
+
+func (gs *GitSentry) CheckPushPolicy(remote string, updates []gate.RefUpdate) (*gate.Report, error) {
+	cfg, err := gs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	
+	if cfg.PushPolicy.Mode == gate.ModeOff {
+		return &gate.Report{Mode: gate.ModeOff}, nil
+	}
+	
+	repo, err := gs.repository()
+	if err != nil {
+		return nil, err
+	}
+	
+	return gate.CheckPush(repo, remote, updates, cfg.PushPolicy)
+}
+
+func (gs *GitSentry) CheckStagedSecrets() (*gate.Report, error) {
+	cfg, err := gs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	
+	report := &gate.Report{Mode: cfg.Secrets.Mode}
+	if cfg.Secrets.Mode == gate.ModeOff {
+		return report, nil
+	}
+	
+	scanner, baseline, err := gs.secretScanner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	
+	repo, err := gs.repository()
+	if err != nil {
+		return nil, err
+	}
+	
+	stat, err := repo.StagedDiffStat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read staged changes: %w", err)
+	}
+	
+	for _, file := range stat.Files {
+		if file.Binary || file.Added == 0 {
+			continue
+		}
+		
+		content, err := repo.StagedContent(file.Path)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to read staged %s: %w", file.Path, err)
+		}
+		
+		for _, finding := range scanner.Filter(scanner.Scan(file.Path, content), baseline) {
+			report.Violations = append(report.Violations, gate.Violation{
+				Path:    fmt.Sprintf("%s:%d", finding.Path, finding.Line),
+				Message: fmt.Sprintf("possible %s %s (fingerprint %s)", finding.Description, finding.Redacted, finding.Fingerprint),
+			})
 		}
-		gs.config = cfg
 	}
 	
-	return gs.config, nil
+	return report, nil
 }
 
-func (gs *GitSentry) onFileChange(path string) {
-	if gs.state == nil {
-		return
+func (gs *GitSentry) ScanSecrets(all bool) ([]secrets.Finding, error) {
+	cfg, err := gs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 	
-	gs.state.IncrementFilesChanged()
+	scanner, baseline, err := gs.secretScanner(cfg)
+	if err != nil {
+		return nil, err
+	}
 	
-	gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
-	gs.state.Save(gitsentryDir)
+	findings, err := gs.scanFiles(scanner, all)
+	if err != nil {
+		return nil, err
+	}
+	
+	return scanner.Filter(findings, baseline), nil
 }
 
-func (gs *GitSentry) monitorLoop() {
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+func (gs *GitSentry) WriteSecretsBaseline() (int, error) {
+	cfg, err := gs.GetConfig()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load config: %w", err)
+	}
 	
-	for gs.isRunning {
-		select {
-		case <-ticker.C:
-			gs.checkCommitSuggestion()
-			gs.checkPushSuggestion()
+	scanner, _, err := gs.secretScanner(cfg)
+	if err != nil {
+		return 0, err
+	}
+	
+	findings, err := gs.scanFiles(scanner, true)
+	if err != nil {
+		return 0, err
+	}
+	findings = scanner.Filter(findings, nil)
+	
+	path := secrets.BaselinePath(filepath.Join(gs.repoPath, ".gitsentry"))
+	if err := secrets.NewBaseline(findings).Save(path); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	
+	return len(findings), nil
+}
+
+func (gs *GitSentry) secretScanner(cfg *config.Config) (*secrets.Scanner, *secrets.Baseline, error) {
+	scanner, err := secrets.NewScanner(cfg.Secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	baseline, err := secrets.LoadBaseline(secrets.BaselinePath(filepath.Join(gs.repoPath, ".gitsentry")))
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return scanner, baseline, nil
+}
+
+func (gs *GitSentry) scanFiles(scanner *secrets.Scanner, all bool) ([]secrets.Finding, error) {
+	repo, err := gs.repository()
+	if err != nil {
+		return nil, err
+	}
+	
+	var files []string
+	if all {
+		tracked, err := repo.TrackedFiles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tracked files: %w", err)
+		}
+		untracked, err := repo.GetUntrackedFiles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list untracked files: %w", err)
+		}
+		files = append(tracked, untracked...)
+	} else {
+		files, err = repo.GetChangedFiles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changed files: %w", err)
+		}
+	}
+	
+	var findings []secrets.Finding
+	for _, file := range files {
+		found, err := scanner.ScanFile(gs.repoPath, file)
+		if err != nil {
+			continue
 		}
+		findings = append(findings, found...)
+	}
+	
+	return findings, nil
+}
+
+func (gs *GitSentry) RecordBypass(hook, reason string, violations []gate.Violation) error {
+	branch := ""
+	if repo, err := gs.repository(); err == nil {
+		branch, _ = repo.GetBranch()
+	}
+	
+	return gate.RecordBypass(filepath.Join(gs.repoPath, ".gitsentry"), hook, branch, reason, violations)
+}
+
+func (gs *GitSentry) repository() (*git.Repository, error) {
+	if gs.gitRepo != nil {
+		return gs.gitRepo, nil
 	}
+	
+	return git.NewRepository(gs.repoPath)
 }
 
-func (gs *GitSentry) checkCommitSuggestion() {
-	if gs.config == nil || gs.state == nil || gs.gitRepo == nil {
+func (gs *GitSentry) monitorOptions() monitor.Options {
+	return monitor.Options{
+		Debounce:       time.Duration(gs.config.Monitor.DebounceMillis) * time.Millisecond,
+		BurstThreshold: gs.config.Monitor.BurstThreshold,
+		Budget:         gs.options.WatchBudget,
+	}
+}
+
+func (gs *GitSentry) onFileChange(batch monitor.Batch) {
+	if gs.state == nil {
 		return
 	}
 	
-	if !gs.config.AutoSuggestCommits {
+	switch batch.Kind {
+	case monitor.BatchEdit:
+		for _, path := range batch.Paths() {
+			gs.state.MarkFileChanged(gs.relativePath(path))
+		}
+		gs.recordActivity(batch.Events)
+	case monitor.BatchBurst:
+		gs.state.RecordActivity()
+		if gs.gitRepo == nil {
+			gs.state.SetChangedFiles(append(gs.state.GetChangedFiles(), gs.relativePaths(batch.Paths())...))
+		}
+	case monitor.BatchBranchSwitch, monitor.BatchRebase:
+		gs.checkRefs()
+	case monitor.BatchDependencyInstall:
 		return
 	}
 	
-	filesChanged, linesAdded, linesRemoved, lastCommit, _ := gs.state.GetStats()
+	gs.refreshWorkingTree()
 	
-	shouldSuggest := false
+	gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
+	gs.state.Save(gitsentryDir)
+}
+
+func (gs *GitSentry) refreshWorkingTree() {
+	if gs.gitRepo == nil || gs.state == nil {
+		return
+	}
 	
-	if filesChanged >= gs.config.Rules.MaxFilesChanged {
-		shouldSuggest = true
+	if files, err := gs.gitRepo.GetChangedFiles(); err == nil {
+		gs.state.SetChangedFiles(files)
+		if tracker := gs.secretTracker(); tracker != nil {
+			tracker.Update(files)
+		}
 	}
 	
-	if linesAdded+linesRemoved >= gs.config.Rules.MaxLinesChanged {
-		shouldSuggest = true
+	stat, err := gs.gitRepo.DiffStat()
+	if err != nil {
+		return
 	}
 	
-	if time.Since(lastCommit).Minutes() >= float64(gs.config.Rules.MaxMinutesSinceCommit) {
-		shouldSuggest = true
+	gs.state.SetLineStats(stat.Added, stat.Removed)
+}
+
+func (gs *GitSentry) relativePaths(paths []string) []string {
+	rel := make([]string, len(paths))
+	for i, path := range paths {
+		rel[i] = gs.relativePath(path)
 	}
 	
-	if shouldSuggest {
-		fmt.Println("\nGitSentry suggests it's a good time to commit!")
-		fmt.Printf("   Files changed: %d\n", filesChanged)
-		fmt.Printf("   Lines changed: %d\n", linesAdded+linesRemoved)
-		fmt.Printf("   Time since last commit: %.0f minutes\n", time.Since(lastCommit).Minutes())
-		fmt.Println("   Run 'git add . && git commit' when ready")
+	return rel
+}
+
+func (gs *GitSentry) relativePath(path string) string {
+	rel, err := filepath.Rel(gs.repoPath, path)
+	if err != nil {
+		return filepath.ToSlash(path)
 	}
+	
+	return filepath.ToSlash(rel)
 }
 
-func (gs *GitSentry) checkPushSuggestion() {
-	if gs.config == nil || gs.gitRepo == nil {
+func (gs *GitSentry) checkRefs() {
+	if gs.gitRepo == nil || gs.state == nil {
 		return
 	}
 	
-	if !gs.config.AutoSuggestPushes {
+	gs.refsMu.Lock()
+	defer gs.refsMu.Unlock()
+	
+	branch, _ := gs.gitRepo.GetBranch()
+	head, _ := gs.gitRepo.GetHeadCommit()
+	upstream, _ := gs.gitRepo.GetUpstreamCommit()
+	
+	lastBranch, lastHead, lastUpstream := gs.state.GetRefs()
+	if branch == lastBranch && head == lastHead && upstream == lastUpstream {
 		return
 	}
 	
-	unpushed, err := gs.gitRepo.GetUnpushedCommitsCount()
-	if err != nil {
-		return
+	firstSnapshot := lastBranch == "" && lastHead == "" && lastUpstream == ""
+	
+	if !firstSnapshot {
+		moved := head != "" && head != lastHead
+		if moved && branch == lastBranch && (lastHead == "" || gs.gitRepo.IsAncestor(lastHead, head)) {
+			gs.state.RecordCommit()
+		}
+		if moved || branch != lastBranch {
+			gs.refreshWorkingTree()
+		}
+		
+		if upstream != "" && upstream != lastUpstream && upstream == head {
+			gs.state.RecordPush()
+		}
 	}
 	
-	if unpushed >= gs.config.Rules.MaxUnpushedCommits {
-		fmt.Println("\nGitSentry suggests pushing your commits for backup!")
-		fmt.Printf("   Unpushed commits: %d\n", unpushed)
-		fmt.Println("   Run 'git push' when ready")
+	gs.state.SetRefs(branch, head, upstream)
+	
+	gitsentryDir := filepath.Join(gs.repoPath, ".gitsentry")
+	gs.state.Save(gitsentryDir)
+}
+
+func (gs *GitSentry) monitorLoop() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ticker.C:
+			gs.Tick()
+		case <-gs.done:
+			return
+		}
 	}
 }
 
+func (gs *GitSentry) Tick() {
+	gs.checkRefs()
+	gs.checkSuggestions()
+	gs.flushNotifications()
+}
+
+func (gs *GitSentry) RepoPath() string {
+	return gs.repoPath
+}
+
 func (gs *GitSentry) addToGitignore() error {
 	gitignorePath := filepath.Join(gs.repoPath, ".gitignore")
 	
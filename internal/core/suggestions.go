@@ -0,0 +1,345 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/gate"
+	"gitsentry/internal/monitor"
+	"gitsentry/internal/notify"
+	"gitsentry/internal/rules"
+	"gitsentry/internal/schedule"
+	"gitsentry/internal/secrets"
+	"gitsentry/internal/state"
+)
+
+func (gs *GitSentry) applyConfig(cfg *config.Config) error {
+	ruleSet, err := rules.BuildScoped(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
+	}
+	
+	notifier, err := notify.Build(cfg.Notifiers, filepath.Join(gs.repoPath, ".gitsentry"))
+	if err != nil {
+		return fmt.Errorf("failed to load notifiers: %w", err)
+	}
+	
+	sched, err := schedule.New(cfg.Schedule)
+	if err != nil {
+		return fmt.Errorf("failed to load schedule: %w", err)
+	}
+	
+	var tracker *secrets.Tracker
+	if cfg.Secrets.Mode != gate.ModeOff {
+		tracker, err = secrets.NewTracker(gs.repoPath, filepath.Join(gs.repoPath, ".gitsentry"), cfg.Secrets)
+		if err != nil {
+			return fmt.Errorf("failed to load secret scanner: %w", err)
+		}
+		if gs.state != nil {
+			tracker.Update(gs.state.GetChangedFiles())
+		}
+	}
+	
+	pause := time.Duration(cfg.NaturalBreak.PauseSeconds) * time.Second
+	
+	gs.mu.Lock()
+	gs.config = cfg
+	gs.ruleSet = ruleSet
+	gs.secrets = tracker
+	gs.notifier = notifier
+	gs.sched = sched
+	if gs.activity == nil {
+		gs.activity = monitor.NewActivity(pause)
+	} else {
+		gs.activity.SetBurstGap(pause)
+	}
+	gs.mu.Unlock()
+	
+	return nil
+}
+
+func (gs *GitSentry) secretTracker() *secrets.Tracker {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	
+	return gs.secrets
+}
+
+func (gs *GitSentry) recordActivity(events int) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	
+	if gs.activity == nil || gs.config == nil {
+		return
+	}
+	
+	gs.activity.Record(time.Now(), events)
+	if !gs.isRunning.Load() {
+		return
+	}
+	
+	pause := time.Duration(gs.config.NaturalBreak.PauseSeconds) * time.Second
+	if pause <= 0 {
+		return
+	}
+	
+	if gs.breakTimer != nil {
+		gs.breakTimer.Stop()
+	}
+	gs.breakTimer = time.AfterFunc(pause, gs.onNaturalBreak)
+}
+
+func (gs *GitSentry) onNaturalBreak() {
+	if !gs.isRunning.Load() {
+		return
+	}
+	
+	gs.checkSuggestions()
+}
+
+func (gs *GitSentry) checkSuggestions() {
+	gs.suggestMu.Lock()
+	defer gs.suggestMu.Unlock()
+	
+	cfg := gs.currentConfig()
+	if cfg == nil || gs.state == nil || gs.gitRepo == nil || gs.isSnoozed() {
+		return
+	}
+	
+	gs.mu.RLock()
+	ruleSet, notifier, sched, activity := gs.ruleSet, gs.notifier, gs.sched, gs.activity
+	gs.mu.RUnlock()
+	
+	if notifier == nil || ruleSet == nil {
+		return
+	}
+	
+	snapshot := gs.snapshot(cfg, ruleSet.PathScoped())
+	if activity != nil {
+		stats := activity.Stats(snapshot.Now)
+		snapshot.InBurst = stats.InBurst
+		snapshot.Idle = stats.Idle
+	}
+	
+	active := make(map[string]bool)
+	grouped := make(map[rules.Action][]rules.Suggestion)
+	for _, evaluation := range ruleSet.Evaluate(snapshot) {
+		suggestion, key := evaluation.Suggestion, evaluation.Key()
+		active[key] = true
+		
+		if suggestion.Action == rules.ActionCommit && snapshot.InBurst && !rules.IsUrgent(evaluation.Config, suggestion.ID) {
+			continue
+		}
+		
+		record, seen := gs.state.GetSuggestion(key)
+		if !shouldEmit(record, seen, suggestion.Value, snapshot.Now, rules.Cooldown(evaluation.Config, suggestion.ID), cfg.Suggestions.GrowthPercent) {
+			continue
+		}
+		
+		gs.state.RecordSuggestion(key, suggestion.Value, snapshot.Now)
+		grouped[suggestion.Action] = append(grouped[suggestion.Action], suggestion)
+	}
+	gs.state.PruneSuggestions(active)
+	
+	if len(grouped) > 0 {
+		gs.state.Save(filepath.Join(gs.repoPath, ".gitsentry"))
+	}
+	
+	hold, reason := false, ""
+	if sched != nil {
+		hold, reason = sched.Hold(snapshot.Now, gs.state.GetLastActivity())
+	}
+	
+	outgoing := make(map[rules.Action]notify.Notification)
+	if !hold {
+		outgoing = gs.releaseHeld()
+	}
+	
+	for action, suggestions := range grouped {
+		n := gs.notification(action, snapshot, suggestions)
+		if hold && n.Severity() != rules.SeverityHigh {
+			gs.holdNotification(n, reason)
+			continue
+		}
+		outgoing[action] = n
+	}
+	
+	for _, action := range []rules.Action{rules.ActionReview, rules.ActionCommit, rules.ActionPush} {
+		n, ok := outgoing[action]
+		if !ok {
+			continue
+		}
+		
+		if err := notifier.Notify(n); err != nil {
+			fmt.Fprintf(os.Stderr, "GitSentry: failed to deliver %s suggestion: %v\n", action, err)
+		}
+	}
+}
+
+func (gs *GitSentry) holdNotification(n notify.Notification, reason string) {
+	if previous, ok := gs.state.GetHeld(string(n.Action)); ok {
+		var held notify.Notification
+		if err := json.Unmarshal(previous.Notification, &held); err == nil {
+			n.Time = held.Time
+		}
+	}
+	
+	data, err := json.Marshal(n)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "GitSentry: failed to hold %s suggestion: %v\n", n.Action, err)
+		return
+	}
+	
+	gs.state.Hold(string(n.Action), state.HeldSuggestion{Reason: reason, Notification: data})
+	gs.state.Save(filepath.Join(gs.repoPath, ".gitsentry"))
+}
+
+func (gs *GitSentry) releaseHeld() map[rules.Action]notify.Notification {
+	held := gs.state.TakeHeld()
+	if len(held) == 0 {
+		return make(map[rules.Action]notify.Notification)
+	}
+	gs.state.Save(filepath.Join(gs.repoPath, ".gitsentry"))
+	
+	_, _, _, lastCommit, lastPush := gs.state.GetStats()
+	
+	released := make(map[rules.Action]notify.Notification)
+	for _, h := range held {
+		var n notify.Notification
+		if err := json.Unmarshal(h.Notification, &n); err != nil {
+			continue
+		}
+		
+		if n.Action == rules.ActionCommit && lastCommit.After(n.Time) {
+			continue
+		}
+		if n.Action == rules.ActionPush && lastPush.After(n.Time) {
+			continue
+		}
+		
+		n.Lines = append(n.Lines, fmt.Sprintf("Held during %s since %s", h.Reason, n.Time.Format("15:04")))
+		released[n.Action] = n
+	}
+	
+	return released
+}
+
+func shouldEmit(record state.SuggestionRecord, seen bool, value int, now time.Time, cooldown time.Duration, growthPercent int) bool {
+	if !seen {
+		return true
+	}
+	
+	if now.Sub(record.LastEmitted) < cooldown {
+		return false
+	}
+	
+	return value*100 >= record.Value*(100+growthPercent)
+}
+
+func (gs *GitSentry) flushNotifications() {
+	gs.mu.RLock()
+	notifier := gs.notifier
+	gs.mu.RUnlock()
+	
+	flusher, ok := notifier.(notify.Flusher)
+	if !ok {
+		return
+	}
+	
+	if err := flusher.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "GitSentry: failed to flush notifications: %v\n", err)
+	}
+}
+
+func (gs *GitSentry) notification(action rules.Action, snapshot rules.Snapshot, suggestions []rules.Suggestion) notify.Notification {
+	n := notify.Notification{
+		RepoPath:    gs.repoPath,
+		Action:      action,
+		Suggestions: suggestions,
+		Time:        snapshot.Now,
+	}
+	
+	switch action {
+	case rules.ActionCommit:
+		n.Title = "GitSentry suggests it's a good time to commit!"
+		n.Lines = []string{
+			fmt.Sprintf("Files changed: %d", snapshot.FilesChanged),
+			fmt.Sprintf("Lines changed: %d", snapshot.LinesChanged()),
+		}
+		if elapsed, ok := snapshot.SinceLastCommit(); ok {
+			n.Lines = append(n.Lines, fmt.Sprintf("Time since last commit: %.0f minutes", elapsed.Minutes()))
+		}
+		n.Lines = append(n.Lines, scopeLines(suggestions)...)
+		n.Lines = append(n.Lines, "Run 'git add . && git commit' when ready")
+	case rules.ActionPush:
+		n.Title = "GitSentry suggests pushing your commits for backup!"
+		n.Lines = []string{
+			fmt.Sprintf("Unpushed commits: %d", snapshot.UnpushedCommits),
+		}
+		n.Lines = append(n.Lines, scopeLines(suggestions)...)
+		n.Lines = append(n.Lines, "Run 'git push' when ready")
+	case rules.ActionReview:
+		n.Title = "GitSentry found possible secrets in your changes!"
+		for _, suggestion := range suggestions {
+			n.Lines = append(n.Lines, suggestion.Reasons...)
+		}
+		n.Lines = append(n.Lines, "Remove them before committing, or run 'gitsentry secrets baseline' to accept known findings")
+	}
+	
+	return n
+}
+
+func scopeLines(suggestions []rules.Suggestion) []string {
+	var lines []string
+	for _, suggestion := range suggestions {
+		if suggestion.Scope == "" {
+			continue
+		}
+		lines = append(lines, suggestion.Reasons...)
+	}
+	
+	return lines
+}
+
+func (gs *GitSentry) snapshot(cfg *config.Config, fileStats bool) rules.Snapshot {
+	filesChanged, linesAdded, linesRemoved, lastCommit, _ := gs.state.GetStats()
+	branch, _, _ := gs.state.GetRefs()
+	if current, err := gs.gitRepo.GetBranch(); err == nil && current != "" {
+		branch = current
+	}
+	
+	snapshot := rules.Snapshot{
+		Branch:       branch,
+		ChangedFiles: gs.state.GetChangedFiles(),
+		FilesChanged: filesChanged,
+		LinesAdded:   linesAdded,
+		LinesRemoved: linesRemoved,
+		LastCommit:   lastCommit,
+		Now:          time.Now(),
+	}
+	
+	if cfg.AutoSuggestPushes {
+		if unpushed, err := gs.gitRepo.GetUnpushedCommitsCount(); err == nil {
+			snapshot.UnpushedCommits = unpushed
+		}
+	}
+	
+	if tracker := gs.secretTracker(); tracker != nil {
+		snapshot.Secrets = tracker.Findings()
+	}
+	
+	if fileStats {
+		if stat, err := gs.gitRepo.DiffStat(); err == nil {
+			snapshot.Files = make(map[string]rules.FileChange, len(stat.Files))
+			for _, file := range stat.Files {
+				snapshot.Files[file.Path] = rules.FileChange{Added: file.Added, Removed: file.Removed}
+			}
+		}
+	}
+	
+	return snapshot
+}
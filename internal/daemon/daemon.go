@@ -1,20 +1,76 @@
 package daemon
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 )
 
+const (
+	childEnv       = "GITSENTRY_DAEMON_CHILD"
+	readyFDEnv     = "GITSENTRY_READY_FD"
+	readyMessage   = "ready"
+	DefaultTimeout = 10 * time.Second
+)
+
+const killTimeout = 2 * time.Second
+
+type StopOutcome string
+
+const (
+	StopNotRunning StopOutcome = "not_running"
+	StopStalePID   StopOutcome = "stale_pid"
+	StopGraceful   StopOutcome = "stopped"
+	StopKilled     StopOutcome = "killed"
+)
+
+type StopResult struct {
+	PID     int
+	Outcome StopOutcome
+}
+
 type Daemon struct {
+	workDir string
 	pidFile string
+	logFile string
 }
 
 func NewDaemon(workDir string) *Daemon {
 	pidFile := filepath.Join(workDir, ".gitsentry", "gitsentry.pid")
-	return &Daemon{pidFile: pidFile}
+	logFile := filepath.Join(workDir, ".gitsentry", "logs", "daemon.log")
+	return &Daemon{workDir: workDir, pidFile: pidFile, logFile: logFile}
+}
+
+func NewSupervisorDaemon(configDir string) *Daemon {
+	return &Daemon{
+		workDir: configDir,
+		pidFile: filepath.Join(configDir, "supervisor.pid"),
+		logFile: filepath.Join(configDir, "logs", "supervisor.log"),
+	}
+}
+
+var detachedChild = readDetachedChild()
+
+func readDetachedChild() bool {
+	child := os.Getenv(childEnv) == "1"
+	os.Unsetenv(childEnv)
+	
+	return child
+}
+
+func IsDetachedChild() bool {
+	return detachedChild
+}
+
+func (d *Daemon) LogFile() string {
+	return d.logFile
 }
 
 func (d *Daemon) WritePID() error {
@@ -30,7 +86,7 @@ func (d *Daemon) ReadPID() (int, error) {
 		return 0, err
 	}
 	
-	return strconv.Atoi(string(data))
+	return strconv.Atoi(strings.TrimSpace(string(data)))
 }
 
 func (d *Daemon) IsRunning() bool {
@@ -39,6 +95,57 @@ func (d *Daemon) IsRunning() bool {
 		return false
 	}
 	
+	return processAlive(pid)
+}
+
+func (d *Daemon) Stop(timeout time.Duration, force bool) (StopResult, error) {
+	if _, err := os.Stat(d.pidFile); os.IsNotExist(err) {
+		return StopResult{Outcome: StopNotRunning}, nil
+	}
+	
+	pid, err := d.ReadPID()
+	if err != nil || pid <= 0 {
+		return StopResult{Outcome: StopStalePID}, d.RemovePID()
+	}
+	
+	result := StopResult{PID: pid}
+	
+	if !processAlive(pid) || !ownsProcess(pid) {
+		result.Outcome = StopStalePID
+		return result, d.RemovePID()
+	}
+	
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return result, err
+	}
+	
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		return result, fmt.Errorf("failed to signal daemon (PID %d): %w", pid, err)
+	}
+	
+	if waitForExit(pid, timeout) {
+		result.Outcome = StopGraceful
+		return result, d.RemovePID()
+	}
+	
+	if !force {
+		return result, fmt.Errorf("daemon (PID %d) did not exit within %s, use --force to kill it", pid, timeout)
+	}
+	
+	if err := process.Signal(syscall.SIGKILL); err != nil {
+		return result, fmt.Errorf("failed to kill daemon (PID %d): %w", pid, err)
+	}
+	
+	if !waitForExit(pid, killTimeout) {
+		return result, fmt.Errorf("daemon (PID %d) survived SIGKILL", pid)
+	}
+	
+	result.Outcome = StopKilled
+	return result, d.RemovePID()
+}
+
+func processAlive(pid int) bool {
 	process, err := os.FindProcess(pid)
 	if err != nil {
 		return false
@@ -48,22 +155,35 @@ func (d *Daemon) IsRunning() bool {
 	return err == nil
 }
 
-func (d *Daemon) Stop() error {
-	pid, err := d.ReadPID()
+func ownsProcess(pid int) bool {
+	if _, err := os.Stat("/proc/self/cmdline"); err != nil {
+		return true
+	}
+	
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
 	if err != nil {
-		return err
+		return false
 	}
 	
-	process, err := os.FindProcess(pid)
+	executable, err := os.Executable()
 	if err != nil {
-		return err
+		return true
 	}
 	
-	if err := process.Signal(os.Interrupt); err != nil {
-		return err
+	argv0 := strings.SplitN(string(data), "\x00", 2)[0]
+	return filepath.Base(argv0) == filepath.Base(executable)
+}
+
+func waitForExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(pid) {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
 	}
 	
-	return d.RemovePID()
+	return !processAlive(pid)
 }
 
 func (d *Daemon) RemovePID() error {
@@ -80,4 +200,68 @@ func (d *Daemon) Daemonize() error {
 	}
 	
 	return d.WritePID()
-}
\ No newline at end of file
+}
+
+func (d *Daemon) NotifyReady() {
+	d.notify(readyMessage)
+}
+
+func (d *Daemon) NotifyFailure(err error) {
+	d.notify("error: " + err.Error())
+}
+
+func (d *Daemon) notify(message string) {
+	fd, err := strconv.Atoi(os.Getenv(readyFDEnv))
+	if err != nil {
+		return
+	}
+	os.Unsetenv(readyFDEnv)
+	
+	pipe := os.NewFile(uintptr(fd), "gitsentry-ready")
+	if pipe == nil {
+		return
+	}
+	defer pipe.Close()
+	
+	fmt.Fprintln(pipe, message)
+}
+
+func (d *Daemon) WaitForShutdown(stop <-chan struct{}) string {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+	
+	select {
+	case sig := <-c:
+		return fmt.Sprintf("received %s", sig)
+	case <-stop:
+		return "shutdown requested"
+	}
+}
+
+func waitForReady(pipe *os.File, timeout time.Duration) error {
+	result := make(chan error, 1)
+	
+	go func() {
+		line, err := bufio.NewReader(pipe).ReadString('\n')
+		line = strings.TrimSpace(line)
+		
+		switch {
+		case line == readyMessage:
+			result <- nil
+		case strings.HasPrefix(line, "error: "):
+			result <- errors.New(strings.TrimPrefix(line, "error: "))
+		case err != nil:
+			result <- errors.New("daemon exited before becoming ready")
+		default:
+			result <- fmt.Errorf("unexpected readiness message: %q", line)
+		}
+	}()
+	
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("daemon did not become ready within %s", timeout)
+	}
+}
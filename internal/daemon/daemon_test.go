@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForReady(t *testing.T) {
+	tests := []struct {
+		message string
+		close   bool
+		wantErr string
+	}{
+		{message: "ready\n", wantErr: ""},
+		{message: "error: failed to load config\n", wantErr: "failed to load config"},
+		{close: true, wantErr: "exited before becoming ready"},
+		{wantErr: "did not become ready"},
+	}
+	
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("Failed to create pipe: %v", err)
+		}
+		
+		if test.message != "" {
+			fmt.Fprint(w, test.message)
+		}
+		if test.close || test.message != "" {
+			w.Close()
+		}
+		
+		err = waitForReady(r, 100*time.Millisecond)
+		
+		if test.wantErr == "" && err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		
+		if test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)) {
+			t.Errorf("Expected error containing %q, got %v", test.wantErr, err)
+		}
+		
+		r.Close()
+		w.Close()
+	}
+}
+
+func TestDaemonPIDLifecycle(t *testing.T) {
+	tempDir := "test_daemon"
+	os.MkdirAll(tempDir+"/.gitsentry", 0755)
+	defer os.RemoveAll(tempDir)
+	
+	d := NewDaemon(tempDir)
+	
+	if d.IsRunning() {
+		t.Error("Daemon should not be running without a PID file")
+	}
+	
+	if err := d.Daemonize(); err != nil {
+		t.Fatalf("Daemonize failed: %v", err)
+	}
+	
+	if !d.IsRunning() {
+		t.Error("Daemon should be running after writing own PID")
+	}
+	
+	if err := d.Daemonize(); err == nil {
+		t.Error("Second Daemonize should fail while running")
+	}
+	
+	d.RemovePID()
+	
+	if d.IsRunning() {
+		t.Error("Daemon should not be running after removing PID file")
+	}
+}
+
+func TestStopOutcomes(t *testing.T) {
+	tempDir := "test_daemon_stop"
+	os.MkdirAll(tempDir+"/.gitsentry", 0755)
+	defer os.RemoveAll(tempDir)
+	
+	d := NewDaemon(tempDir)
+	
+	result, err := d.Stop(time.Second, false)
+	if err != nil || result.Outcome != StopNotRunning {
+		t.Errorf("Expected not running, got %v (%v)", result.Outcome, err)
+	}
+	
+	exited := exec.Command("true")
+	if err := exited.Run(); err != nil {
+		t.Skipf("Cannot run helper process: %v", err)
+	}
+	os.WriteFile(d.pidFile, []byte(strconv.Itoa(exited.Process.Pid)), 0644)
+	
+	result, err = d.Stop(time.Second, false)
+	if err != nil || result.Outcome != StopStalePID {
+		t.Errorf("Expected stale PID, got %v (%v)", result.Outcome, err)
+	}
+	if _, err := os.Stat(d.pidFile); !os.IsNotExist(err) {
+		t.Error("Stale PID file should be removed")
+	}
+	
+	sleeper := exec.Command("sleep", "30")
+	if err := sleeper.Start(); err != nil {
+		t.Skipf("Cannot start helper process: %v", err)
+	}
+	defer sleeper.Process.Kill()
+	go sleeper.Wait()
+	os.WriteFile(d.pidFile, []byte(strconv.Itoa(sleeper.Process.Pid)), 0644)
+	
+	if _, err := os.Stat("/proc/self/cmdline"); err == nil {
+		result, err = d.Stop(time.Second, false)
+		if err != nil || result.Outcome != StopStalePID {
+			t.Errorf("Expected a foreign process to be treated as stale PID, got %v (%v)", result.Outcome, err)
+		}
+		if !processAlive(sleeper.Process.Pid) {
+			t.Error("Should not signal a process that is not gitsentry")
+		}
+	}
+	
+	helper := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	helper.Env = append(os.Environ(), "GITSENTRY_TEST_HELPER=1")
+	if err := helper.Start(); err != nil {
+		t.Skipf("Cannot start helper process: %v", err)
+	}
+	go helper.Wait()
+	os.WriteFile(d.pidFile, []byte(strconv.Itoa(helper.Process.Pid)), 0644)
+	
+	result, err = d.Stop(5*time.Second, false)
+	if err != nil || result.Outcome != StopGraceful {
+		t.Errorf("Expected graceful stop, got %v (%v)", result.Outcome, err)
+	}
+}
+
+func TestDetachedChildMarkerIsNotInherited(t *testing.T) {
+	t.Setenv(childEnv, "1")
+	
+	if !readDetachedChild() {
+		t.Error("Should recognize the detached child marker")
+	}
+	
+	if _, ok := os.LookupEnv(childEnv); ok {
+		t.Errorf("%s should be cleared so subprocesses do not inherit it", childEnv)
+	}
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GITSENTRY_TEST_HELPER") != "1" {
+		return
+	}
+	
+	time.Sleep(30 * time.Second)
+	os.Exit(0)
+}
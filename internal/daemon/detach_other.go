@@ -0,0 +1,12 @@
+//go:build !unix
+
+package daemon
+
+import (
+	"fmt"
+	"time"
+)
+
+func (d *Daemon) Detach(args []string, timeout time.Duration) (int, error) {
+	return 0, fmt.Errorf("background daemon mode is not supported on this platform, use --foreground")
+}
@@ -0,0 +1,77 @@
+//go:build unix
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+func (d *Daemon) Detach(args []string, timeout time.Duration) (int, error) {
+	if d.IsRunning() {
+		return 0, fmt.Errorf("daemon already running")
+	}
+	
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("failed to locate executable: %w", err)
+	}
+	
+	workDir, err := filepath.Abs(d.workDir)
+	if err != nil {
+		return 0, err
+	}
+	
+	if err := os.MkdirAll(filepath.Dir(d.logFile), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create logs directory: %w", err)
+	}
+	
+	logFile, err := os.OpenFile(d.logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open daemon log: %w", err)
+	}
+	defer logFile.Close()
+	
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		return 0, err
+	}
+	defer devNull.Close()
+	
+	readyRead, readyWrite, err := os.Pipe()
+	if err != nil {
+		return 0, err
+	}
+	defer readyRead.Close()
+	
+	cmd := exec.Command(executable, args...)
+	cmd.Dir = workDir
+	cmd.Stdin = devNull
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.ExtraFiles = []*os.File{readyWrite}
+	cmd.Env = append(os.Environ(), childEnv+"=1", readyFDEnv+"=3")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	
+	if err := cmd.Start(); err != nil {
+		readyWrite.Close()
+		return 0, fmt.Errorf("failed to start daemon process: %w", err)
+	}
+	readyWrite.Close()
+	
+	if err := waitForReady(readyRead, timeout); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		d.RemovePID()
+		return 0, fmt.Errorf("%w (see %s)", err, d.logFile)
+	}
+	
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+	
+	return pid, nil
+}
@@ -0,0 +1,171 @@
+package gate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/git"
+	"gitsentry/internal/security"
+)
+
+const (
+	ModeOff   = "off"
+	ModeWarn  = "warn"
+	ModeBlock = "block"
+	
+	BypassEnv = "GITSENTRY_BYPASS"
+	AuditFile = "audit.log"
+)
+
+type Limits struct {
+	MaxFiles    int
+	MaxLines    int
+	MaxFileSize int64
+	AllowBinary bool
+}
+
+func CommitLimits(cfg *config.Config) Limits {
+	limits := Limits{
+		MaxFiles:    cfg.CommitGate.MaxFiles,
+		MaxLines:    cfg.CommitGate.MaxLines,
+		MaxFileSize: int64(cfg.CommitGate.MaxFileSizeKB) * 1024,
+		AllowBinary: cfg.CommitGate.AllowBinary,
+	}
+	
+	if limits.MaxFiles == 0 {
+		limits.MaxFiles = cfg.Rules.MaxFilesChanged
+	}
+	if limits.MaxLines == 0 {
+		limits.MaxLines = cfg.Rules.MaxLinesChanged
+	}
+	
+	return limits
+}
+
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	
+	return v.Path + ": " + v.Message
+}
+
+type Report struct {
+	Mode       string
+	Files      int
+	Lines      int
+	Binary     int
+	Commits    int
+	Violations []Violation
+}
+
+func (r *Report) Blocked() bool {
+	return r.Mode == ModeBlock && len(r.Violations) > 0
+}
+
+func CheckCommit(stat *git.DiffStat, stagedSize func(path string) (int64, error), mode string, limits Limits) *Report {
+	report := &Report{
+		Mode:  mode,
+		Files: len(stat.Files),
+		Lines: stat.Added + stat.Removed,
+	}
+	
+	if limits.MaxFiles > 0 && report.Files > limits.MaxFiles {
+		report.add("", "%d files staged, the limit is %d", report.Files, limits.MaxFiles)
+	}
+	if limits.MaxLines > 0 && report.Lines > limits.MaxLines {
+		report.add("", "%d lines changed (+%d -%d), the limit is %d", report.Lines, stat.Added, stat.Removed, limits.MaxLines)
+	}
+	
+	for _, file := range stat.Files {
+		if file.Binary {
+			report.Binary++
+			if !limits.AllowBinary {
+				report.add(file.Path, "binary files are not allowed")
+			}
+		}
+		
+		if limits.MaxFileSize <= 0 {
+			continue
+		}
+		
+		size, err := stagedSize(file.Path)
+		if err != nil {
+			continue
+		}
+		if size > limits.MaxFileSize {
+			report.add(file.Path, "file is %s, the limit is %s", formatSize(size), formatSize(limits.MaxFileSize))
+		}
+	}
+	
+	return report
+}
+
+func (r *Report) add(path, msg string, args ...interface{}) {
+	r.Violations = append(r.Violations, Violation{Path: path, Message: fmt.Sprintf(msg, args...)})
+}
+
+func Bypass() (string, bool) {
+	reason, ok := os.LookupEnv(BypassEnv)
+	if !ok {
+		return "", false
+	}
+	
+	reason = strings.TrimSpace(reason)
+	return reason, reason != ""
+}
+
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Hook       string    `json:"hook"`
+	User       string    `json:"user,omitempty"`
+	Branch     string    `json:"branch,omitempty"`
+	Reason     string    `json:"reason"`
+	Violations []string  `json:"violations"`
+}
+
+func RecordBypass(gitsentryDir, hook, branch, reason string, violations []Violation) error {
+	entry := AuditEntry{
+		Time:   time.Now().UTC(),
+		Hook:   hook,
+		Branch: branch,
+		Reason: reason,
+	}
+	
+	if current, err := user.Current(); err == nil {
+		entry.User = current.Username
+	}
+	
+	for _, violation := range violations {
+		entry.Violations = append(entry.Violations, violation.String())
+	}
+	
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	
+	return security.SecureAppendFile(filepath.Join(gitsentryDir, "logs", AuditFile), append(data, '\n'))
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d bytes", size)
+	}
+}
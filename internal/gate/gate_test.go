@@ -0,0 +1,123 @@
+package gate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/git"
+)
+
+func TestCommitLimitsFallBackToRules(t *testing.T) {
+	cfg := config.DefaultConfig()
+	
+	limits := CommitLimits(cfg)
+	if limits.MaxFiles != cfg.Rules.MaxFilesChanged || limits.MaxLines != cfg.Rules.MaxLinesChanged {
+		t.Errorf("Unset gate limits should fall back to rules, got %+v", limits)
+	}
+	
+	cfg.CommitGate.MaxFiles = 40
+	cfg.CommitGate.MaxLines = 900
+	limits = CommitLimits(cfg)
+	if limits.MaxFiles != 40 || limits.MaxLines != 900 || limits.MaxFileSize != 1024*1024 {
+		t.Errorf("Gate limits should take precedence, got %+v", limits)
+	}
+}
+
+func TestCheckCommit(t *testing.T) {
+	sizes := map[string]int64{"small.go": 14, "big.json": 4096, "logo.png": 5}
+	stagedSize := func(path string) (int64, error) {
+		size, ok := sizes[path]
+		if !ok {
+			return 0, os.ErrNotExist
+		}
+		return size, nil
+	}
+	
+	stat := &git.DiffStat{
+		Files: []git.FileDiffStat{
+			{Path: "small.go", Added: 30, Removed: 5},
+			{Path: "big.json", Added: 60},
+			{Path: "logo.png", Binary: true},
+			{Path: "deleted.txt", Removed: 10},
+		},
+		Added:   90,
+		Removed: 15,
+	}
+	
+	report := CheckCommit(stat, stagedSize, ModeBlock, Limits{MaxFiles: 10, MaxLines: 200, MaxFileSize: 8192, AllowBinary: true})
+	if len(report.Violations) != 0 || report.Blocked() {
+		t.Errorf("Should pass within limits, got %+v", report.Violations)
+	}
+	if report.Files != 4 || report.Lines != 105 || report.Binary != 1 {
+		t.Errorf("Unexpected totals: %+v", report)
+	}
+	
+	report = CheckCommit(stat, stagedSize, ModeBlock, Limits{MaxFiles: 3, MaxLines: 100, MaxFileSize: 1024})
+	var messages []string
+	for _, violation := range report.Violations {
+		messages = append(messages, violation.String())
+	}
+	expected := []string{
+		"4 files staged, the limit is 3",
+		"105 lines changed (+90 -15), the limit is 100",
+		"big.json: file is 4.0 KB, the limit is 1.0 KB",
+		"logo.png: binary files are not allowed",
+	}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected violations:\n%s", strings.Join(messages, "\n"))
+	}
+	if !report.Blocked() {
+		t.Error("Block mode with violations should block")
+	}
+	
+	report = CheckCommit(stat, stagedSize, ModeWarn, Limits{MaxFiles: 3, AllowBinary: true})
+	if len(report.Violations) != 1 || report.Blocked() {
+		t.Errorf("Warn mode should report without blocking, got %+v", report)
+	}
+}
+
+func TestBypassIsAudited(t *testing.T) {
+	tempDir := "test_gate_audit"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	t.Setenv(BypassEnv, "  ")
+	if _, ok := Bypass(); ok {
+		t.Error("Blank bypass reason should not bypass the gate")
+	}
+	
+	t.Setenv(BypassEnv, "vendored update")
+	reason, ok := Bypass()
+	if !ok || reason != "vendored update" {
+		t.Fatalf("Expected bypass reason, got %q (%v)", reason, ok)
+	}
+	
+	violations := []Violation{{Message: "12 files staged, the limit is 5"}}
+	for i := 0; i < 2; i++ {
+		if err := RecordBypass(tempDir, "pre-commit", "main", reason, violations); err != nil {
+			t.Fatalf("Failed to record bypass: %v", err)
+		}
+	}
+	
+	data, err := os.ReadFile(filepath.Join(tempDir, "logs", AuditFile))
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Audit log should be appended to, got %d lines", len(lines))
+	}
+	
+	var entry AuditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Audit entry should be JSON: %v", err)
+	}
+	if entry.Hook != "pre-commit" || entry.Branch != "main" || entry.Reason != "vendored update" || len(entry.Violations) != 1 || entry.Time.IsZero() {
+		t.Errorf("Unexpected audit entry: %+v", entry)
+	}
+}
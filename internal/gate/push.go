@@ -0,0 +1,143 @@
+package gate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/git"
+)
+
+var wipSubject = regexp.MustCompile(`(?i)^(\[?wip\b|fixup! |squash! |amend! )`)
+
+type RefUpdate struct {
+	LocalRef  string
+	LocalSHA  string
+	RemoteRef string
+	RemoteSHA string
+}
+
+func (u RefUpdate) Deletes() bool {
+	return isZero(u.LocalSHA)
+}
+
+func (u RefUpdate) Creates() bool {
+	return isZero(u.RemoteSHA)
+}
+
+func (u RefUpdate) Branch() (string, bool) {
+	return strings.CutPrefix(u.RemoteRef, "refs/heads/")
+}
+
+func ParseRefUpdates(r io.Reader) ([]RefUpdate, error) {
+	var updates []RefUpdate
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("malformed pre-push line: %q", scanner.Text())
+		}
+		
+		updates = append(updates, RefUpdate{
+			LocalRef:  fields[0],
+			LocalSHA:  fields[1],
+			RemoteRef: fields[2],
+			RemoteSHA: fields[3],
+		})
+	}
+	
+	return updates, scanner.Err()
+}
+
+func CheckPush(repo *git.Repository, remote string, updates []RefUpdate, policy config.PushPolicy) (*Report, error) {
+	for _, pattern := range append(append([]string{}, policy.ProtectedBranches...), policy.SharedBranches...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+		}
+	}
+	
+	report := &Report{Mode: policy.Mode}
+	for _, update := range updates {
+		branch, ok := update.Branch()
+		if !ok {
+			continue
+		}
+		
+		if matchBranch(policy.ProtectedBranches, branch) {
+			if update.Deletes() {
+				report.add(branch, "deleting a protected branch is not allowed")
+			} else {
+				report.add(branch, "direct pushes to a protected branch are not allowed, open a pull request instead")
+			}
+		}
+		
+		if update.Deletes() {
+			continue
+		}
+		
+		commits, err := repo.Commits(update.LocalSHA, pushedExclusions(repo, remote, update)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits for %s: %w", update.RemoteRef, err)
+		}
+		report.Commits += len(commits)
+		
+		if policy.MaxCommits > 0 && len(commits) > policy.MaxCommits {
+			report.add(branch, "%d commits in one push, the limit is %d", len(commits), policy.MaxCommits)
+		}
+		
+		shared := matchBranch(policy.SharedBranches, branch) || matchBranch(policy.ProtectedBranches, branch)
+		for _, commit := range commits {
+			if shared && !policy.AllowWIP && wipSubject.MatchString(commit.Subject) {
+				report.add(branch, "commit %s %q is work in progress, squash or reword it before pushing to a shared branch", commit.ShortHash(), commit.Subject)
+			}
+			if policy.RequireSignoff && !commit.IsMerge() && !signedOff(commit) {
+				report.add(branch, "commit %s %q has no Signed-off-by for %s, amend it with 'git commit --amend -s'", commit.ShortHash(), commit.Subject, commit.AuthorEmail)
+			}
+		}
+	}
+	
+	return report, nil
+}
+
+func pushedExclusions(repo *git.Repository, remote string, update RefUpdate) []string {
+	if !update.Creates() && repo.HasCommit(update.RemoteSHA) {
+		return []string{update.RemoteSHA}
+	}
+	
+	if remote == "" || strings.ContainsAny(remote, "/:") {
+		return []string{"--remotes"}
+	}
+	
+	return []string{"--remotes=" + remote}
+}
+
+func signedOff(commit git.Commit) bool {
+	for _, signoff := range commit.Trailers("Signed-off-by") {
+		if strings.Contains(signoff, "<"+commit.AuthorEmail+">") {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func matchBranch(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func isZero(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
@@ -0,0 +1,131 @@
+package gate
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/git"
+)
+
+func TestParseRefUpdates(t *testing.T) {
+	input := "refs/heads/feature 1111111111111111111111111111111111111111 refs/heads/feature 0000000000000000000000000000000000000000\n\n" +
+		"(delete) 0000000000000000000000000000000000000000 refs/heads/old 2222222222222222222222222222222222222222\n"
+	
+	updates, err := ParseRefUpdates(strings.NewReader(input))
+	if err != nil || len(updates) != 2 {
+		t.Fatalf("Expected 2 updates, got %d (%v)", len(updates), err)
+	}
+	
+	if !updates[0].Creates() || updates[0].Deletes() || !updates[1].Deletes() {
+		t.Errorf("Unexpected update kinds: %+v", updates)
+	}
+	
+	if branch, ok := updates[1].Branch(); !ok || branch != "old" {
+		t.Errorf("Expected branch old, got %q", branch)
+	}
+	
+	if _, err := ParseRefUpdates(strings.NewReader("refs/heads/main abc\n")); err == nil {
+		t.Error("Should reject malformed lines")
+	}
+}
+
+func TestCheckPush(t *testing.T) {
+	tempDir := "test_push_policy"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tempDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	commit := func(name string, args ...string) {
+		os.WriteFile(filepath.Join(tempDir, name), []byte(name+"\n"), 0644)
+		run("add", ".")
+		run(append([]string{"commit", "-q"}, args...)...)
+	}
+	
+	run("init", "-q")
+	run("config", "user.name", "GitSentry Test")
+	run("config", "user.email", "test@gitsentry.local")
+	run("config", "commit.gpgsign", "false")
+	
+	commit("a.txt", "-s", "-m", "initial")
+	base := run("rev-parse", "HEAD")
+	commit("b.txt", "-s", "-m", "add b")
+	commit("c.txt", "-m", "WIP: try c")
+	commit("d.txt", "-s", "-m", "fixup! add b")
+	tip := run("rev-parse", "HEAD")
+	
+	repo, err := git.NewRepository(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	
+	policy := config.DefaultPushPolicy()
+	policy.Mode = ModeBlock
+	
+	feature := []RefUpdate{{LocalRef: "refs/heads/feature", LocalSHA: tip, RemoteRef: "refs/heads/feature", RemoteSHA: base}}
+	report, err := CheckPush(repo, "origin", feature, policy)
+	if err != nil {
+		t.Fatalf("CheckPush failed: %v", err)
+	}
+	if len(report.Violations) != 0 || report.Commits != 3 {
+		t.Errorf("WIP commits on feature branches should pass, got %+v", report)
+	}
+	
+	policy.ProtectedBranches = []string{"main"}
+	policy.RequireSignoff = true
+	policy.MaxCommits = 2
+	
+	main := []RefUpdate{{LocalRef: "refs/heads/main", LocalSHA: tip, RemoteRef: "refs/heads/main", RemoteSHA: base}}
+	report, err = CheckPush(repo, "origin", main, policy)
+	if err != nil {
+		t.Fatalf("CheckPush failed: %v", err)
+	}
+	
+	var messages []string
+	for _, violation := range report.Violations {
+		messages = append(messages, violation.Message)
+	}
+	joined := strings.Join(messages, "\n")
+	for _, expected := range []string{
+		"direct pushes to a protected branch are not allowed",
+		"3 commits in one push, the limit is 2",
+		`"fixup! add b" is work in progress`,
+		`"WIP: try c" is work in progress`,
+		`"WIP: try c" has no Signed-off-by for test@gitsentry.local`,
+	} {
+		if !strings.Contains(joined, expected) {
+			t.Errorf("Expected violation %q, got:\n%s", expected, joined)
+		}
+	}
+	if len(report.Violations) != 5 || !report.Blocked() {
+		t.Errorf("Expected 5 blocking violations, got %d", len(report.Violations))
+	}
+	
+	created := []RefUpdate{{LocalRef: "refs/heads/topic", LocalSHA: base, RemoteRef: "refs/heads/topic", RemoteSHA: strings.Repeat("0", 40)}}
+	report, err = CheckPush(repo, "origin", created, policy)
+	if err != nil || report.Commits != 1 || len(report.Violations) != 0 {
+		t.Errorf("New branch without remote refs should check its whole history, got %+v (%v)", report, err)
+	}
+	
+	deleted := []RefUpdate{{LocalRef: "(delete)", LocalSHA: strings.Repeat("0", 40), RemoteRef: "refs/heads/main", RemoteSHA: base}}
+	report, _ = CheckPush(repo, "origin", deleted, policy)
+	if len(report.Violations) != 1 || !strings.Contains(report.Violations[0].Message, "deleting a protected branch") {
+		t.Errorf("Deleting a protected branch should be reported, got %+v", report.Violations)
+	}
+	
+	policy.SharedBranches = []string{"["}
+	if _, err := CheckPush(repo, "origin", main, policy); err == nil {
+		t.Error("Should reject invalid branch patterns")
+	}
+}
@@ -0,0 +1,186 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	binarySniffLen = 8000
+	emptyTree      = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+)
+
+type FileDiffStat struct {
+	Path      string
+	Added     int
+	Removed   int
+	Binary    bool
+	Untracked bool
+}
+
+type DiffStat struct {
+	Files   []FileDiffStat
+	Added   int
+	Removed int
+}
+
+func (d *DiffStat) add(file FileDiffStat) {
+	d.Files = append(d.Files, file)
+	d.Added += file.Added
+	d.Removed += file.Removed
+}
+
+func (r *Repository) DiffStat() (*DiffStat, error) {
+	stat := &DiffStat{}
+	
+	base := emptyTree
+	if r.HasHead() {
+		base = "HEAD"
+	}
+	
+	output, err := r.execGitCommand("diff", "--numstat", "--no-renames", "-z", base)
+	if err != nil {
+		return nil, err
+	}
+	parseNumstat(output, stat)
+	
+	untracked, err := r.GetUntrackedFiles()
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, path := range untracked {
+		lines, binary, err := countLines(filepath.Join(r.path, path))
+		if err != nil {
+			continue
+		}
+		stat.add(FileDiffStat{Path: path, Added: lines, Binary: binary, Untracked: true})
+	}
+	
+	return stat, nil
+}
+
+func (r *Repository) StagedDiffStat() (*DiffStat, error) {
+	stat := &DiffStat{}
+	
+	output, err := r.execGitCommand("diff", "--cached", "--numstat", "--no-renames", "-z")
+	if err != nil {
+		return nil, err
+	}
+	parseNumstat(output, stat)
+	
+	return stat, nil
+}
+
+func (r *Repository) StagedContent(path string) ([]byte, error) {
+	return r.execGitCommand("show", ":"+path)
+}
+
+func (r *Repository) StagedSize(path string) (int64, error) {
+	output, err := r.execGitCommand("cat-file", "-s", ":"+path)
+	if err != nil {
+		return 0, err
+	}
+	
+	return strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+}
+
+func (r *Repository) TrackedFiles() ([]string, error) {
+	output, err := r.execGitCommand("ls-files", "-z")
+	if err != nil {
+		return nil, err
+	}
+	
+	return splitNul(output), nil
+}
+
+func (r *Repository) HasHead() bool {
+	_, err := r.execGitCommand("rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+func (r *Repository) GetUntrackedFiles() ([]string, error) {
+	output, err := r.execGitCommand("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	
+	return splitNul(output), nil
+}
+
+func parseNumstat(output []byte, stat *DiffStat) {
+	for _, record := range splitNul(output) {
+		fields := strings.SplitN(record, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		
+		file := FileDiffStat{Path: fields[2]}
+		if fields[0] == "-" && fields[1] == "-" {
+			file.Binary = true
+		} else {
+			file.Added, _ = strconv.Atoi(fields[0])
+			file.Removed, _ = strconv.Atoi(fields[1])
+		}
+		
+		stat.add(file)
+	}
+}
+
+func splitNul(output []byte) []string {
+	var items []string
+	for _, item := range strings.Split(string(output), "\x00") {
+		item = strings.TrimPrefix(item, "\n")
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	
+	return items
+}
+
+func countLines(path string) (int, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+	
+	reader := bufio.NewReader(f)
+	head, err := reader.Peek(binarySniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return 0, false, err
+	}
+	
+	if bytes.IndexByte(head, 0) >= 0 {
+		return 0, true, nil
+	}
+	
+	lines := 0
+	lastByte := byte('\n')
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			lastByte = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+	
+	if lastByte != '\n' {
+		lines++
+	}
+	
+	return lines, false, nil
+}
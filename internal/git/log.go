@@ -0,0 +1,91 @@
+package git
+
+import (
+	"strings"
+)
+
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+type Commit struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Subject     string
+	Message     string
+	Parents     int
+}
+
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	
+	return c.Hash
+}
+
+func (c Commit) IsMerge() bool {
+	return c.Parents > 1
+}
+
+func (c Commit) Trailers(key string) []string {
+	var values []string
+	prefix := strings.ToLower(key) + ":"
+	for _, line := range strings.Split(c.Message, "\n") {
+		if strings.HasPrefix(strings.ToLower(line), prefix) {
+			values = append(values, strings.TrimSpace(line[len(prefix):]))
+		}
+	}
+	
+	return values
+}
+
+func (r *Repository) Commits(tip string, exclude ...string) ([]Commit, error) {
+	args := []string{"log", "--format=%H%x1f%an%x1f%ae%x1f%P%x1f%B%x1e", tip}
+	if len(exclude) > 0 {
+		args = append(args, "--not")
+		args = append(args, exclude...)
+	}
+	
+	output, err := r.execGitCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	
+	return parseCommits(output), nil
+}
+
+func (r *Repository) HasCommit(rev string) bool {
+	_, err := r.execGitCommand("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	return err == nil
+}
+
+func (r *Repository) IsAncestor(ancestor, descendant string) bool {
+	_, err := r.execGitCommand("merge-base", "--is-ancestor", ancestor, descendant)
+	return err == nil
+}
+
+func parseCommits(output []byte) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(string(output), recordSep) {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), fieldSep, 5)
+		if len(fields) != 5 {
+			continue
+		}
+		
+		message := strings.TrimRight(fields[4], "\n")
+		subject, _, _ := strings.Cut(message, "\n")
+		commits = append(commits, Commit{
+			Hash:        fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			Subject:     subject,
+			Message:     message,
+			Parents:     len(strings.Fields(fields[3])),
+		})
+	}
+	
+	return commits
+}
@@ -0,0 +1,230 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const maxUntrackedPatchBytes = 256 * 1024
+
+type FileStatus string
+
+const (
+	StatusAdded    FileStatus = "added"
+	StatusModified FileStatus = "modified"
+	StatusDeleted  FileStatus = "deleted"
+	StatusRenamed  FileStatus = "renamed"
+)
+
+type Hunk struct {
+	Context string
+	Added   []string
+	Removed []string
+}
+
+type FilePatch struct {
+	Path    string
+	OldPath string
+	Status  FileStatus
+	Binary  bool
+	Added   int
+	Removed int
+	Hunks   []Hunk
+}
+
+func (r *Repository) Patch() ([]FilePatch, error) {
+	base := emptyTree
+	if r.HasHead() {
+		base = "HEAD"
+	}
+	
+	output, err := r.execGitCommand("diff", "--no-color", "--no-ext-diff", "--unified=0", "--find-renames", base)
+	if err != nil {
+		return nil, err
+	}
+	patches := ParsePatch(output)
+	
+	untracked, err := r.GetUntrackedFiles()
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, path := range untracked {
+		patch, err := untrackedPatch(r.path, path)
+		if err != nil {
+			continue
+		}
+		patches = append(patches, patch)
+	}
+	
+	return patches, nil
+}
+
+func ParsePatch(data []byte) []FilePatch {
+	var patches []FilePatch
+	var current *FilePatch
+	inHunk := false
+	
+	finish := func() {
+		if current != nil {
+			patches = append(patches, *current)
+		}
+	}
+	
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		
+		if strings.HasPrefix(line, "diff --git ") {
+			finish()
+			oldPath, newPath := splitDiffHeader(strings.TrimPrefix(line, "diff --git "))
+			current = &FilePatch{Path: newPath, OldPath: oldPath, Status: StatusModified}
+			inHunk = false
+			continue
+		}
+		
+		if current == nil {
+			continue
+		}
+		
+		if inHunk {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				current.Hunks = append(current.Hunks, Hunk{Context: hunkContext(line)})
+			case strings.HasPrefix(line, "+"):
+				hunk := &current.Hunks[len(current.Hunks)-1]
+				hunk.Added = append(hunk.Added, line[1:])
+				current.Added++
+			case strings.HasPrefix(line, "-"):
+				hunk := &current.Hunks[len(current.Hunks)-1]
+				hunk.Removed = append(hunk.Removed, line[1:])
+				current.Removed++
+			}
+			continue
+		}
+		
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			current.Hunks = append(current.Hunks, Hunk{Context: hunkContext(line)})
+			inHunk = true
+		case strings.HasPrefix(line, "new file mode"):
+			current.Status = StatusAdded
+		case strings.HasPrefix(line, "deleted file mode"):
+			current.Status = StatusDeleted
+		case strings.HasPrefix(line, "rename from "):
+			current.Status = StatusRenamed
+			current.OldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			current.Path = unquotePath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "Binary files "), strings.HasPrefix(line, "GIT binary patch"):
+			current.Binary = true
+		case strings.HasPrefix(line, "--- "):
+			if path := strings.TrimPrefix(unquotePath(strings.TrimPrefix(line, "--- ")), "a/"); path != "/dev/null" {
+				current.OldPath = path
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if path := strings.TrimPrefix(unquotePath(strings.TrimPrefix(line, "+++ ")), "b/"); path != "/dev/null" {
+				current.Path = path
+			}
+		}
+	}
+	finish()
+	
+	for i := range patches {
+		if patches[i].Status != StatusRenamed {
+			patches[i].OldPath = ""
+		}
+		if patches[i].Status == StatusDeleted && patches[i].Path == "" {
+			patches[i].Path = patches[i].OldPath
+		}
+	}
+	
+	return patches
+}
+
+func untrackedPatch(root, path string) (FilePatch, error) {
+	patch := FilePatch{Path: path, Status: StatusAdded}
+	
+	lines, binary, err := countLines(filepath.Join(root, path))
+	if err != nil {
+		return patch, err
+	}
+	
+	if binary {
+		patch.Binary = true
+		return patch, nil
+	}
+	
+	patch.Added = lines
+	
+	f, err := os.Open(filepath.Join(root, path))
+	if err != nil {
+		return patch, err
+	}
+	defer f.Close()
+	
+	hunk := Hunk{}
+	scanner := bufio.NewScanner(io.LimitReader(f, maxUntrackedPatchBytes))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		hunk.Added = append(hunk.Added, scanner.Text())
+	}
+	patch.Hunks = []Hunk{hunk}
+	
+	return patch, nil
+}
+
+func splitDiffHeader(header string) (string, string) {
+	if strings.HasPrefix(header, `"`) {
+		if end := closingQuote(header); end > 0 {
+			oldPath := unquotePath(header[:end+1])
+			newPath := unquotePath(strings.TrimSpace(header[end+1:]))
+			return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(newPath, "b/")
+		}
+	}
+	
+	if i := strings.Index(header, " b/"); i >= 0 {
+		return strings.TrimPrefix(header[:i], "a/"), header[i+3:]
+	}
+	
+	return header, header
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	
+	return -1
+}
+
+func unquotePath(path string) string {
+	path = strings.TrimSuffix(path, "\t")
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			return unquoted
+		}
+	}
+	
+	return path
+}
+
+func hunkContext(line string) string {
+	rest := strings.TrimPrefix(line, "@@")
+	if end := strings.Index(rest, "@@"); end >= 0 {
+		return strings.TrimSpace(rest[end+2:])
+	}
+	
+	return ""
+}
@@ -56,15 +56,23 @@ func (r *Repository) GetStatus() ([]string, error) {
 }
 
 func (r *Repository) GetChangedFiles() ([]string, error) {
-	status, err := r.GetStatus()
+	output, err := r.execGitCommand("status", "--porcelain", "-z", "--untracked-files=all")
 	if err != nil {
 		return nil, err
 	}
 	
 	var files []string
-	for _, line := range status {
-		if len(line) > 3 {
-			files = append(files, line[3:])
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		
+		files = append(files, entry[3:])
+		
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
 		}
 	}
 	
@@ -107,6 +115,42 @@ func (r *Repository) GetBranch() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+func (r *Repository) GitDir() string {
+	return filepath.Join(r.path, ".git")
+}
+
+func (r *Repository) HooksDir() (string, error) {
+	output, err := r.execGitCommand("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.path, dir)
+	}
+	
+	return dir, nil
+}
+
+func (r *Repository) GetHeadCommit() (string, error) {
+	output, err := r.execGitCommand("rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (r *Repository) GetUpstreamCommit() (string, error) {
+	output, err := r.execGitCommand("rev-parse", "--verify", "--quiet", "@{u}")
+	if err != nil {
+		return "", err
+	}
+	
+	return strings.TrimSpace(string(output)), nil
+}
+
 func (r *Repository) execGitCommand(args ...string) ([]byte, error) {
 	sanitizedArgs, err := security.SanitizeGitArgs(args)
 	if err != nil {
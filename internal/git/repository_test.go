@@ -2,6 +2,8 @@ package git
 
 import (
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 )
 
@@ -132,4 +134,238 @@ func TestHasRemote(t *testing.T) {
 	} else {
 		t.Logf("Has remote: %t", hasRemote)
 	}
-}
\ No newline at end of file
+}
+
+func TestDiffStat(t *testing.T) {
+	tempDir := "test_diffstat"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	initTestRepo(t, tempDir)
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+	runGit(t, tempDir, "add", "main.go")
+	runGit(t, tempDir, "commit", "-m", "initial")
+	
+	repo, err := NewRepository(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	
+	stat, err := repo.DiffStat()
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	if stat.Added != 0 || stat.Removed != 0 {
+		t.Errorf("Expected clean diff, got +%d -%d", stat.Added, stat.Removed)
+	}
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(1)\n}\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "staged.go"), []byte("package main\n\nvar x = 1\n"), 0644)
+	runGit(t, tempDir, "add", "staged.go")
+	os.WriteFile(filepath.Join(tempDir, "notes.txt"), []byte("one\ntwo"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "blob.bin"), []byte{0, 1, 2, 3}, 0644)
+	
+	stat, err = repo.DiffStat()
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	
+	if stat.Added != 8 {
+		t.Errorf("Expected 8 added lines, got %d", stat.Added)
+	}
+	
+	if stat.Removed != 1 {
+		t.Errorf("Expected 1 removed line, got %d", stat.Removed)
+	}
+	
+	binary := false
+	for _, file := range stat.Files {
+		if file.Path == "blob.bin" {
+			binary = file.Binary
+		}
+	}
+	if !binary {
+		t.Error("Untracked binary file should be reported as binary")
+	}
+}
+
+func TestStagedSize(t *testing.T) {
+	tempDir := "test_staged_size"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	initTestRepo(t, tempDir)
+	
+	os.WriteFile(filepath.Join(tempDir, "data.txt"), []byte("small\n"), 0644)
+	runGit(t, tempDir, "add", "data.txt")
+	os.WriteFile(filepath.Join(tempDir, "data.txt"), make([]byte, 4096), 0644)
+	
+	repo, err := NewRepository(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	
+	size, err := repo.StagedSize("data.txt")
+	if err != nil || size != 6 {
+		t.Errorf("Expected the staged size 6, not the working tree size, got %d (%v)", size, err)
+	}
+	
+	if _, err := repo.StagedSize("missing.txt"); err == nil {
+		t.Error("Unstaged path should have no staged size")
+	}
+}
+
+func initTestRepo(t *testing.T, dir string) {
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.name", "GitSentry Test")
+	runGit(t, dir, "config", "user.email", "test@gitsentry.local")
+	runGit(t, dir, "config", "commit.gpgsign", "false")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func TestGetChangedFiles(t *testing.T) {
+	tempDir := "test_changed_files"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	initTestRepo(t, tempDir)
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n"), 0644)
+	runGit(t, tempDir, "add", "main.go")
+	runGit(t, tempDir, "commit", "-m", "initial")
+	
+	repo, err := NewRepository(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+	os.MkdirAll(filepath.Join(tempDir, "pkg", "util"), 0755)
+	os.WriteFile(filepath.Join(tempDir, "pkg", "util", "a.go"), []byte("package util\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "pkg", "util", "b.go"), []byte("package util\n"), 0644)
+	
+	files, err := repo.GetChangedFiles()
+	if err != nil {
+		t.Fatalf("GetChangedFiles failed: %v", err)
+	}
+	
+	if len(files) != 3 {
+		t.Errorf("Expected 3 changed files, got %v", files)
+	}
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n"), 0644)
+	
+	files, err = repo.GetChangedFiles()
+	if err != nil {
+		t.Fatalf("GetChangedFiles failed: %v", err)
+	}
+	
+	for _, file := range files {
+		if file == "main.go" {
+			t.Error("Reverted file should not be reported as changed")
+		}
+	}
+}
+
+func TestPatch(t *testing.T) {
+	tempDir := "test_patch"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	initTestRepo(t, tempDir)
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(1)\n}\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "old.go"), []byte("package main\n"), 0644)
+	runGit(t, tempDir, "add", ".")
+	runGit(t, tempDir, "commit", "-m", "initial")
+	
+	os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(2)\n}\n"), 0644)
+	os.Remove(filepath.Join(tempDir, "old.go"))
+	os.WriteFile(filepath.Join(tempDir, "new file.go"), []byte("package main\n\nfunc helper() {}\n"), 0644)
+	
+	repo, err := NewRepository(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	
+	patches, err := repo.Patch()
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	
+	byPath := make(map[string]FilePatch)
+	for _, patch := range patches {
+		byPath[patch.Path] = patch
+	}
+	
+	main := byPath["main.go"]
+	if main.Status != StatusModified || main.Added != 1 || main.Removed != 1 {
+		t.Errorf("Expected one-line modification of main.go, got %+v", main)
+	}
+	if len(main.Hunks) != 1 || main.Hunks[0].Context != "func main() {" {
+		t.Errorf("Expected hunk with function context, got %+v", main.Hunks)
+	}
+	
+	if byPath["old.go"].Status != StatusDeleted {
+		t.Errorf("Expected old.go to be deleted, got %+v", byPath["old.go"])
+	}
+	
+	added := byPath["new file.go"]
+	if added.Status != StatusAdded || added.Added != 3 || len(added.Hunks) != 1 {
+		t.Errorf("Expected untracked file as an addition, got %+v", added)
+	}
+}
+
+func TestCommits(t *testing.T) {
+	tempDir := "test_commits"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	initTestRepo(t, tempDir)
+	
+	os.WriteFile(filepath.Join(tempDir, "a.txt"), []byte("a\n"), 0644)
+	runGit(t, tempDir, "add", ".")
+	runGit(t, tempDir, "commit", "-q", "-m", "initial")
+	runGit(t, tempDir, "branch", "base")
+	
+	os.WriteFile(filepath.Join(tempDir, "b.txt"), []byte("b\n"), 0644)
+	runGit(t, tempDir, "add", ".")
+	runGit(t, tempDir, "commit", "-q", "-s", "-m", "add b", "-m", "Explain why.")
+	
+	repo, err := NewRepository(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	
+	all, err := repo.Commits("HEAD")
+	if err != nil || len(all) != 2 {
+		t.Fatalf("Expected 2 commits, got %d (%v)", len(all), err)
+	}
+	
+	commits, err := repo.Commits("HEAD", "base")
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("Expected 1 commit after base, got %d (%v)", len(commits), err)
+	}
+	
+	commit := commits[0]
+	if commit.Subject != "add b" || commit.AuthorEmail != "test@gitsentry.local" || commit.Parents != 1 || len(commit.ShortHash()) != 7 {
+		t.Errorf("Unexpected commit: %+v", commit)
+	}
+	
+	signoffs := commit.Trailers("Signed-off-by")
+	if len(signoffs) != 1 || signoffs[0] != "GitSentry Test <test@gitsentry.local>" {
+		t.Errorf("Expected sign-off trailer, got %v", signoffs)
+	}
+	
+	if !repo.HasCommit(commit.Hash) || repo.HasCommit("0123456789abcdef0123456789abcdef01234567") {
+		t.Error("HasCommit should only report known commits")
+	}
+}
@@ -0,0 +1,138 @@
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitsentry/internal/security"
+)
+
+const (
+	Marker      = "# Installed by GitSentry"
+	ChainSuffix = ".gitsentry-chained"
+	CommitMsg   = "commit-msg"
+	PreCommit   = "pre-commit"
+	PrePush     = "pre-push"
+)
+
+type Hook struct {
+	Name    string
+	Command string
+	Stdin   bool
+}
+
+var Managed = []Hook{
+	{Name: PreCommit, Command: "hook pre-commit"},
+	{Name: CommitMsg, Command: `lint-message "$1"`},
+	{Name: PrePush, Command: `hook pre-push "$@"`, Stdin: true},
+}
+
+type Result struct {
+	Name    string
+	Path    string
+	Chained bool
+	Updated bool
+}
+
+func Install(dir, executable string) ([]Result, error) {
+	var results []Result
+	for _, hook := range Managed {
+		result, err := install(dir, executable, hook)
+		if err != nil {
+			return results, fmt.Errorf("failed to install %s hook: %w", hook.Name, err)
+		}
+		results = append(results, result)
+	}
+	
+	return results, nil
+}
+
+func Uninstall(dir string) ([]string, error) {
+	var removed []string
+	for _, hook := range Managed {
+		path := filepath.Join(dir, hook.Name)
+		if !IsManaged(path) {
+			continue
+		}
+		
+		if err := security.SecureRemoveFileWithin(dir, path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s hook: %w", hook.Name, err)
+		}
+		
+		if _, err := os.Stat(path + ChainSuffix); err == nil {
+			if err := os.Rename(path+ChainSuffix, path); err != nil {
+				return removed, fmt.Errorf("failed to restore previous %s hook: %w", hook.Name, err)
+			}
+		}
+		
+		removed = append(removed, hook.Name)
+	}
+	
+	return removed, nil
+}
+
+func IsManaged(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	
+	return bytes.Contains(data, []byte(Marker))
+}
+
+func Script(hook Hook, executable string) string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString(Marker + ". Remove with: gitsentry hooks uninstall\n")
+	sb.WriteString("GITSENTRY=" + shellQuote(executable) + "\n")
+	sb.WriteString("[ -x \"$GITSENTRY\" ] || GITSENTRY=gitsentry\n\n")
+	
+	feed := ""
+	if hook.Stdin {
+		sb.WriteString("input=$(cat)\n")
+		feed = "printf '%s\\n' \"$input\" | "
+	}
+	
+	sb.WriteString("chained=\"$0" + ChainSuffix + "\"\n")
+	sb.WriteString("if [ -x \"$chained\" ]; then\n")
+	sb.WriteString("\t" + feed + "\"$chained\" \"$@\" || exit $?\n")
+	sb.WriteString("fi\n\n")
+	sb.WriteString(feed + "exec \"$GITSENTRY\" " + hook.Command + "\n")
+	
+	return sb.String()
+}
+
+func install(dir, executable string, hook Hook) (Result, error) {
+	path := filepath.Join(dir, hook.Name)
+	result := Result{Name: hook.Name, Path: path}
+	
+	if _, err := os.Stat(path); err == nil {
+		if IsManaged(path) {
+			result.Updated = true
+		} else {
+			if _, err := os.Stat(path + ChainSuffix); err == nil {
+				return result, fmt.Errorf("both %s and %s exist, move one of them aside", path, path+ChainSuffix)
+			}
+			if err := os.Rename(path, path+ChainSuffix); err != nil {
+				return result, err
+			}
+		}
+	}
+	
+	if _, err := os.Stat(path + ChainSuffix); err == nil {
+		result.Chained = true
+	}
+	
+	if err := security.SecureWriteExecutableWithin(dir, path, []byte(Script(hook, executable))); err != nil {
+		return result, err
+	}
+	
+	return result, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
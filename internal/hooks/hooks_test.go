@@ -0,0 +1,107 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitsentry/internal/security"
+)
+
+func TestInstallChainsAndUninstallRestores(t *testing.T) {
+	tempDir := "test_hooks"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	existing := []byte("#!/bin/sh\necho existing\n")
+	hookPath := filepath.Join(tempDir, CommitMsg)
+	os.WriteFile(hookPath, existing, 0755)
+	
+	results, err := Install(tempDir, "/opt/it's/gitsentry")
+	if err != nil {
+		t.Fatalf("Failed to install hooks: %v", err)
+	}
+	
+	if len(results) != len(Managed) {
+		t.Fatalf("Expected %d hooks installed, got %+v", len(Managed), results)
+	}
+	
+	for _, result := range results {
+		if result.Updated || result.Chained != (result.Name == CommitMsg) {
+			t.Errorf("Expected fresh install chaining only the existing hook, got %+v", result)
+		}
+	}
+	
+	if !IsManaged(hookPath) {
+		t.Fatal("Installed hook should carry the GitSentry marker")
+	}
+	
+	data, _ := os.ReadFile(hookPath)
+	if !strings.Contains(string(data), `GITSENTRY='/opt/it'\''s/gitsentry'`) || !strings.Contains(string(data), `lint-message "$1"`) {
+		t.Errorf("Unexpected hook script:\n%s", data)
+	}
+	
+	prePush, _ := os.ReadFile(filepath.Join(tempDir, PrePush))
+	if !strings.Contains(string(prePush), "input=$(cat)") || !strings.Contains(string(prePush), `| exec "$GITSENTRY" hook pre-push "$@"`) {
+		t.Errorf("pre-push hook should pass the buffered ref list on, got:\n%s", prePush)
+	}
+	
+	info, _ := os.Stat(hookPath)
+	if info.Mode().Perm()&0100 == 0 {
+		t.Error("Installed hook should be executable")
+	}
+	
+	chained, _ := os.ReadFile(hookPath + ChainSuffix)
+	if string(chained) != string(existing) {
+		t.Error("Existing hook should be preserved for chaining")
+	}
+	
+	results, err = Install(tempDir, "gitsentry")
+	if err != nil {
+		t.Fatalf("Failed to reinstall hooks: %v", err)
+	}
+	for _, result := range results {
+		if !result.Updated {
+			t.Errorf("Reinstall should update in place, got %+v", result)
+		}
+	}
+	
+	removed, err := Uninstall(tempDir)
+	if err != nil || len(removed) != len(Managed) {
+		t.Fatalf("Failed to uninstall hooks: %v", err)
+	}
+	
+	restored, _ := os.ReadFile(hookPath)
+	if string(restored) != string(existing) {
+		t.Error("Uninstall should restore the previous hook")
+	}
+	
+	if _, err := os.Stat(hookPath + ChainSuffix); !os.IsNotExist(err) {
+		t.Error("Chained copy should be gone after uninstall")
+	}
+	
+	if _, err := os.Stat(filepath.Join(tempDir, PreCommit)); !os.IsNotExist(err) {
+		t.Error("Hooks without a previous version should be removed")
+	}
+}
+
+func TestInstallIntoAbsoluteHooksDir(t *testing.T) {
+	tempDir := "test_hooks_abs"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	dir, _ := filepath.Abs(tempDir)
+	if _, err := Install(dir, "gitsentry"); err != nil {
+		t.Fatalf("Failed to install hooks into %s: %v", dir, err)
+	}
+	
+	if err := security.ValidateFilePath(filepath.Join(dir, PreCommit)); err == nil {
+		t.Error("Installing hooks should not widen the global path allowlist")
+	}
+	
+	removed, err := Uninstall(dir)
+	if err != nil || len(removed) != len(Managed) {
+		t.Errorf("Failed to uninstall hooks from %s: %v (%v)", dir, removed, err)
+	}
+}
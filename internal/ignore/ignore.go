@@ -0,0 +1,410 @@
+package ignore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	GitIgnoreFile       = ".gitignore"
+	GitSentryIgnoreFile = ".gitsentryignore"
+)
+
+var alwaysIgnored = map[string]bool{
+	".git":       true,
+	".gitsentry": true,
+}
+
+var defaultPatterns = []string{
+	"*.swp",
+	"*.swo",
+	"*~",
+	".#*",
+	"4913",
+}
+
+type pattern struct {
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+type dirPatterns struct {
+	gitignore []pattern
+	sentry    []pattern
+}
+
+type Matcher struct {
+	root     string
+	mu       sync.RWMutex
+	global   []pattern
+	exclude  []pattern
+	dirCache map[string]dirPatterns
+}
+
+func NewMatcher(root string) *Matcher {
+	m := &Matcher{
+		root:     root,
+		dirCache: make(map[string]dirPatterns),
+	}
+	
+	m.global = parsePatterns("", defaultPatterns)
+	if path := globalExcludesFile(); path != "" {
+		m.global = append(m.global, loadPatternFile("", path)...)
+	}
+	
+	m.exclude = loadPatternFile("", filepath.Join(root, ".git", "info", "exclude"))
+	
+	return m
+}
+
+func (m *Matcher) Match(path string, isDir bool) bool {
+	rel := m.relative(path)
+	if rel == "" || rel == "." {
+		return false
+	}
+	
+	parts := strings.Split(rel, "/")
+	for _, part := range parts {
+		if alwaysIgnored[part] {
+			return true
+		}
+	}
+	
+	for i := 1; i < len(parts); i++ {
+		if m.matchOne(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	
+	return m.matchOne(rel, isDir)
+}
+
+func (m *Matcher) Invalidate(dir string) {
+	rel := m.relative(dir)
+	if rel == "." {
+		rel = ""
+	}
+	
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	delete(m.dirCache, rel)
+}
+
+func (m *Matcher) matchOne(rel string, isDir bool) bool {
+	ignored := false
+	
+	for _, set := range m.patternSets(rel) {
+		for _, p := range set {
+			if p.dirOnly && !isDir {
+				continue
+			}
+			
+			if p.matches(rel) {
+				ignored = !p.negate
+			}
+		}
+	}
+	
+	return ignored
+}
+
+func (m *Matcher) patternSets(rel string) [][]pattern {
+	sets := [][]pattern{m.global, m.exclude}
+	
+	dirs := []string{""}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		dirs = append(dirs, strings.Join(parts[:i], "/"))
+	}
+	
+	var sentrySets [][]pattern
+	for _, dir := range dirs {
+		patterns := m.loadDir(dir)
+		sets = append(sets, patterns.gitignore)
+		sentrySets = append(sentrySets, patterns.sentry)
+	}
+	sets = append(sets, sentrySets...)
+	
+	return sets
+}
+
+func (m *Matcher) loadDir(dir string) dirPatterns {
+	m.mu.RLock()
+	patterns, ok := m.dirCache[dir]
+	m.mu.RUnlock()
+	if ok {
+		return patterns
+	}
+	
+	fsDir := filepath.Join(m.root, filepath.FromSlash(dir))
+	patterns = dirPatterns{
+		gitignore: loadPatternFile(dir, filepath.Join(fsDir, GitIgnoreFile)),
+		sentry:    loadPatternFile(dir, filepath.Join(fsDir, GitSentryIgnoreFile)),
+	}
+	
+	m.mu.Lock()
+	m.dirCache[dir] = patterns
+	m.mu.Unlock()
+	
+	return patterns
+}
+
+func (m *Matcher) relative(path string) string {
+	rel := path
+	if r, err := filepath.Rel(m.root, path); err == nil && !strings.HasPrefix(r, "..") {
+		rel = r
+	}
+	
+	return filepath.ToSlash(filepath.Clean(rel))
+}
+
+func (p pattern) matches(rel string) bool {
+	if p.base != "" {
+		if !strings.HasPrefix(rel, p.base+"/") {
+			return false
+		}
+		rel = rel[len(p.base)+1:]
+	}
+	
+	return p.re.MatchString(rel)
+}
+
+func loadPatternFile(base, path string) []pattern {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	
+	return parsePatterns(base, lines)
+}
+
+func parsePatterns(base string, lines []string) []pattern {
+	var patterns []pattern
+	for _, line := range lines {
+		if p, ok := parsePattern(base, line); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	
+	return patterns
+}
+
+func parsePattern(base, line string) (pattern, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpaces(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return pattern{}, false
+	}
+	
+	p := pattern{base: base}
+	
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	
+	if line == "" {
+		return pattern{}, false
+	}
+	
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		line = "**/" + line
+	}
+	
+	re, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return pattern{}, false
+	}
+	p.re = re
+	
+	return p, true
+}
+
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	
+	return line
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/") && (i == 0 || glob[i-1] == '/'):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**") && i+2 == len(glob) && (i == 0 || glob[i-1] == '/'):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			j := i + 1
+			if j < len(glob) && glob[j] == '!' {
+				j++
+			}
+			if j < len(glob) && glob[j] == ']' {
+				j++
+			}
+			end := strings.IndexByte(glob[j:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : j+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = j + end
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	
+	return sb.String()
+}
+
+func globalExcludesFile() string {
+	home, _ := os.UserHomeDir()
+	
+	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
+	if xdgConfig == "" && home != "" {
+		xdgConfig = filepath.Join(home, ".config")
+	}
+	
+	var candidates []string
+	if home != "" {
+		candidates = append(candidates, filepath.Join(home, ".gitconfig"))
+	}
+	if xdgConfig != "" {
+		candidates = append(candidates, filepath.Join(xdgConfig, "git", "config"))
+	}
+	
+	for _, candidate := range candidates {
+		if path := readExcludesFile(candidate); path != "" {
+			return expandHome(path, home)
+		}
+	}
+	
+	if xdgConfig != "" {
+		return filepath.Join(xdgConfig, "git", "ignore")
+	}
+	
+	return ""
+}
+
+func readExcludesFile(gitconfig string) string {
+	data, err := os.ReadFile(gitconfig)
+	if err != nil {
+		return ""
+	}
+	
+	inCore := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		
+		if strings.HasPrefix(line, "[") {
+			section := strings.ToLower(strings.Trim(line, "[] \t"))
+			inCore = section == "core"
+			continue
+		}
+		
+		if !inCore {
+			continue
+		}
+		
+		key, value, found := strings.Cut(line, "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(key), "excludesfile") {
+			continue
+		}
+		
+		return strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	
+	return ""
+}
+
+func expandHome(path, home string) string {
+	if home != "" && (path == "~" || strings.HasPrefix(path, "~/")) {
+		return filepath.Join(home, path[1:])
+	}
+	
+	return path
+}
+
+type PathMatcher struct {
+	patterns []pattern
+}
+
+func NewPathMatcher(globs []string) (*PathMatcher, error) {
+	m := &PathMatcher{}
+	for _, glob := range globs {
+		p, ok := parsePattern("", glob)
+		if !ok {
+			return nil, fmt.Errorf("invalid path pattern: %q", glob)
+		}
+		m.patterns = append(m.patterns, p)
+	}
+	
+	return m, nil
+}
+
+func (m *PathMatcher) Match(path string) bool {
+	rel := filepath.ToSlash(filepath.Clean(path))
+	parts := strings.Split(rel, "/")
+	
+	matched := false
+	for _, p := range m.patterns {
+		for i := 1; i <= len(parts); i++ {
+			isDir := i < len(parts)
+			if p.dirOnly && !isDir {
+				continue
+			}
+			
+			if p.matches(strings.Join(parts[:i], "/")) {
+				matched = !p.negate
+				break
+			}
+		}
+	}
+	
+	return matched
+}
@@ -0,0 +1,143 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatternSemantics(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		match   bool
+	}{
+		{"*.log", "debug.log", false, true},
+		{"*.log", "logs/debug.log", false, true},
+		{"build/", "build", true, true},
+		{"build/", "build", false, false},
+		{"build/", "src/build", true, true},
+		{"/build", "src/build", true, false},
+		{"/build", "build", true, true},
+		{"doc/*.txt", "doc/notes.txt", false, true},
+		{"doc/*.txt", "doc/server/arch.txt", false, false},
+		{"**/foo", "a/b/foo", false, true},
+		{"foo/**", "foo/a/b", false, true},
+		{"a/**/b", "a/b", false, true},
+		{"a/**/b", "a/x/y/b", false, true},
+		{"file[0-9].go", "file7.go", false, true},
+		{"file[!0-9].go", "file7.go", false, false},
+		{"?.txt", "a.txt", false, true},
+		{`\#notes`, "#notes", false, true},
+		{"# comment", "# comment", false, false},
+	}
+	
+	for _, test := range tests {
+		p, ok := parsePattern("", test.pattern)
+		if !ok {
+			if test.match {
+				t.Errorf("Pattern %q should parse", test.pattern)
+			}
+			continue
+		}
+		
+		matched := p.matches(test.path) && (!p.dirOnly || test.isDir)
+		if matched != test.match {
+			t.Errorf("Pattern %q on %q: expected %t, got %t", test.pattern, test.path, test.match, matched)
+		}
+	}
+}
+
+func TestMatcherNestedAndNegation(t *testing.T) {
+	tempDir := "test_ignore"
+	os.MkdirAll(filepath.Join(tempDir, "src", "gen"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "out"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, ".git", "info"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	os.WriteFile(filepath.Join(tempDir, ".gitignore"), []byte("*.log\nout/\n!keep.log\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "src", ".gitignore"), []byte("gen/\n!important.log\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, ".git", "info", "exclude"), []byte("local.txt\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir, ".gitsentryignore"), []byte("docs/generated.md\n"), 0644)
+	
+	m := NewMatcher(tempDir)
+	
+	tests := []struct {
+		path  string
+		isDir bool
+		match bool
+	}{
+		{"debug.log", false, true},
+		{"keep.log", false, false},
+		{"src/important.log", false, false},
+		{"src/other.log", false, true},
+		{"src/gen", true, true},
+		{"src/gen/code.go", false, true},
+		{"out/bin/app", false, true},
+		{"local.txt", false, true},
+		{"docs/generated.md", false, true},
+		{"src/buildinfo.go", false, false},
+		{"templates/index.html", false, false},
+		{".git/HEAD", false, true},
+		{".gitsentry/state.json", false, true},
+		{"main.go.swp", false, true},
+	}
+	
+	for _, test := range tests {
+		got := m.Match(filepath.Join(tempDir, filepath.FromSlash(test.path)), test.isDir)
+		if got != test.match {
+			t.Errorf("Match(%s): expected %t, got %t", test.path, test.match, got)
+		}
+	}
+}
+
+func TestMatcherInvalidate(t *testing.T) {
+	tempDir := "test_ignore_invalidate"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	m := NewMatcher(tempDir)
+	target := filepath.Join(tempDir, "cache.bin")
+	
+	if m.Match(target, false) {
+		t.Fatal("File should not be ignored before .gitignore exists")
+	}
+	
+	os.WriteFile(filepath.Join(tempDir, ".gitignore"), []byte("*.bin\n"), 0644)
+	m.Invalidate(tempDir)
+	
+	if !m.Match(target, false) {
+		t.Error("File should be ignored after invalidating the cache")
+	}
+}
+
+func TestPathMatcher(t *testing.T) {
+	m, err := NewPathMatcher([]string{"docs/", "pkg/billing/**", "!pkg/billing/testdata/**", "*.md"})
+	if err != nil {
+		t.Fatalf("Failed to compile path patterns: %v", err)
+	}
+	
+	tests := []struct {
+		path  string
+		match bool
+	}{
+		{"docs/guide/intro.txt", true},
+		{"docs", false},
+		{"pkg/billing/invoice.go", true},
+		{"pkg/billing/testdata/fixture.json", false},
+		{"pkg/billing/testdata/README.md", true},
+		{"pkg/payments/charge.go", false},
+		{"README.md", true},
+	}
+	
+	for _, test := range tests {
+		if got := m.Match(test.path); got != test.match {
+			t.Errorf("Match(%q): expected %t, got %t", test.path, test.match, got)
+		}
+	}
+	
+	if _, err := NewPathMatcher([]string{"# only a comment"}); err == nil {
+		t.Error("Should reject patterns that match nothing")
+	}
+}
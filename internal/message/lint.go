@@ -0,0 +1,273 @@
+package message
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"gitsentry/internal/config"
+)
+
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+var conventionalHeader = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?(:)?( *)(.*)$`)
+
+var nonImperative = map[string]string{
+	"added":       "add",
+	"adds":        "add",
+	"adding":      "add",
+	"fixed":       "fix",
+	"fixes":       "fix",
+	"fixing":      "fix",
+	"updated":     "update",
+	"updates":     "update",
+	"updating":    "update",
+	"removed":     "remove",
+	"removes":     "remove",
+	"removing":    "remove",
+	"changed":     "change",
+	"changes":     "change",
+	"changing":    "change",
+	"implemented": "implement",
+	"implements":  "implement",
+	"refactored":  "refactor",
+	"refactors":   "refactor",
+	"improved":    "improve",
+	"improves":    "improve",
+	"renamed":     "rename",
+	"renames":     "rename",
+	"moved":       "move",
+	"moves":       "move",
+	"created":     "create",
+	"creates":     "create",
+	"deleted":     "delete",
+	"deletes":     "delete",
+	"made":        "make",
+	"makes":       "make",
+	"wrote":       "write",
+	"writes":      "write",
+	"bumped":      "bump",
+	"bumps":       "bump",
+}
+
+var moodExceptions = map[string]bool{
+	"bring":  true,
+	"string": true,
+	"spring": true,
+	"embed":  true,
+	"shred":  true,
+}
+
+var autosquashPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+type Problem struct {
+	Line    int
+	Column  int
+	Length  int
+	Message string
+	Source  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+func (p Problem) Pointer() string {
+	if p.Source == "" {
+		return ""
+	}
+	
+	length := p.Length
+	if length < 1 {
+		length = 1
+	}
+	
+	return p.Source + "\n" + strings.Repeat(" ", p.Column-1) + strings.Repeat("^", length)
+}
+
+type lintLine struct {
+	number int
+	text   string
+}
+
+func Lint(text, format string, settings config.CommitLint) ([]Problem, error) {
+	var ticket *regexp.Regexp
+	if settings.TicketPattern != "" {
+		var err error
+		ticket, err = regexp.Compile(settings.TicketPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket_pattern %q: %w", settings.TicketPattern, err)
+		}
+	}
+	
+	lines := messageLines(text)
+	if len(lines) == 0 {
+		return []Problem{{Line: 1, Column: 1, Message: "commit message is empty"}}, nil
+	}
+	
+	subject := lines[0]
+	for _, prefix := range autosquashPrefixes {
+		if strings.HasPrefix(subject.text, prefix) {
+			return nil, nil
+		}
+	}
+	
+	var problems []Problem
+	add := func(line lintLine, column, length int, msg string, args ...interface{}) {
+		problems = append(problems, Problem{
+			Line:    line.number,
+			Column:  column,
+			Length:  length,
+			Message: fmt.Sprintf(msg, args...),
+			Source:  line.text,
+		})
+	}
+	
+	description, descriptionColumn := subject.text, 1
+	if format != FormatSimple {
+		description, descriptionColumn = lintConventional(subject, settings, add)
+	}
+	
+	if description != "" {
+		lintDescription(subject, description, descriptionColumn, add)
+	}
+	
+	if max := settings.MaxSubjectLength; max > 0 {
+		if length := utf8.RuneCountInString(subject.text); length > max {
+			add(subject, max+1, length-max, "subject is %d characters long, the limit is %d", length, max)
+		}
+	}
+	
+	if len(lines) > 1 && lines[1].number == subject.number+1 && lines[1].text != "" {
+		add(lines[1], 1, utf8.RuneCountInString(lines[1].text), "separate the subject from the body with a blank line")
+	}
+	
+	if max := settings.MaxBodyLineLength; max > 0 {
+		for _, line := range lines[1:] {
+			length := utf8.RuneCountInString(line.text)
+			if length <= max || !strings.ContainsAny(strings.TrimSpace(line.text), " \t") {
+				continue
+			}
+			add(line, max+1, length-max, "body line is %d characters long, wrap at %d", length, max)
+		}
+	}
+	
+	if ticket != nil {
+		found := false
+		for _, line := range lines {
+			if ticket.MatchString(line.text) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add(subject, 1, 0, "missing ticket reference matching %q", settings.TicketPattern)
+		}
+	}
+	
+	return problems, nil
+}
+
+func lintConventional(subject lintLine, settings config.CommitLint, add func(lintLine, int, int, string, ...interface{})) (string, int) {
+	match := conventionalHeader.FindStringSubmatchIndex(subject.text)
+	if match == nil {
+		add(subject, 1, 1, `subject must start with a type, e.g. "feat: add login page"`)
+		return "", 0
+	}
+	
+	group := func(i int) (string, int) {
+		if match[2*i] < 0 {
+			return "", 0
+		}
+		return subject.text[match[2*i]:match[2*i+1]], utf8.RuneCountInString(subject.text[:match[2*i]]) + 1
+	}
+	
+	typ, typeColumn := group(1)
+	scope, scopeColumn := group(2)
+	_, colonColumn := group(4)
+	spaces, spaceColumn := group(5)
+	description, descriptionColumn := group(6)
+	
+	if colonColumn == 0 {
+		end := utf8.RuneCountInString(typ) + 1
+		if _, bangColumn := group(3); bangColumn > 0 {
+			end = bangColumn + 1
+		} else if scopeColumn > 0 {
+			end = scopeColumn + utf8.RuneCountInString(scope) + 1
+		}
+		add(subject, end, 1, `expected ": " after the type, e.g. "feat: add login page"`)
+		return "", 0
+	}
+	
+	if len(settings.Types) > 0 && !containsString(settings.Types, typ) {
+		add(subject, typeColumn, utf8.RuneCountInString(typ), "type %q is not allowed (use one of: %s)", typ, strings.Join(settings.Types, ", "))
+	}
+	
+	switch {
+	case scopeColumn > 0 && scope == "":
+		add(subject, scopeColumn-1, 2, "scope is empty, remove the parentheses or name a scope")
+	case scopeColumn == 0 && settings.RequireScope:
+		add(subject, utf8.RuneCountInString(typ)+1, 1, `scope is required, e.g. "%s(core): ..."`, typ)
+	case scope != "" && len(settings.Scopes) > 0 && !containsString(settings.Scopes, scope):
+		add(subject, scopeColumn, utf8.RuneCountInString(scope), "scope %q is not allowed (use one of: %s)", scope, strings.Join(settings.Scopes, ", "))
+	}
+	
+	if spaces != " " {
+		add(subject, spaceColumn, utf8.RuneCountInString(spaces)+1, `expected exactly one space after ":"`)
+	}
+	
+	if strings.TrimSpace(description) == "" {
+		add(subject, descriptionColumn, 1, "description after the type is empty")
+		return "", 0
+	}
+	
+	return description, descriptionColumn
+}
+
+func lintDescription(subject lintLine, description string, column int, add func(lintLine, int, int, string, ...interface{})) {
+	word := strings.Fields(description)[0]
+	lower := strings.ToLower(strings.Trim(word, ".,:;!"))
+	
+	if base, ok := nonImperative[lower]; ok {
+		add(subject, column, utf8.RuneCountInString(word), "use the imperative mood: %q instead of %q", base, word)
+	} else if !moodExceptions[lower] && ((strings.HasSuffix(lower, "ing") && len(lower) > 5) || (strings.HasSuffix(lower, "ed") && !strings.HasSuffix(lower, "eed") && len(lower) > 4)) {
+		add(subject, column, utf8.RuneCountInString(word), "use the imperative mood instead of %q", word)
+	}
+	
+	if trimmed := strings.TrimRight(subject.text, " "); strings.HasSuffix(trimmed, ".") && !strings.HasSuffix(trimmed, "...") {
+		add(subject, utf8.RuneCountInString(trimmed), 1, "subject should not end with a period")
+	}
+}
+
+func messageLines(text string) []lintLine {
+	var lines []lintLine
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(lines) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, lintLine{number: i + 1, text: strings.TrimRight(line, " \t")})
+	}
+	
+	for len(lines) > 0 && lines[len(lines)-1].text == "" {
+		lines = lines[:len(lines)-1]
+	}
+	
+	return lines
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	
+	return false
+}
@@ -0,0 +1,128 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"gitsentry/internal/config"
+)
+
+func TestLintConventional(t *testing.T) {
+	settings := config.DefaultCommitLint()
+	
+	tests := []struct {
+		name    string
+		message string
+		line    int
+		column  int
+		problem string
+	}{
+		{"valid", "feat(rules): add scoped overrides\n\nEvaluate rules per path glob.\n", 0, 0, ""},
+		{"breaking", "feat(api)!: drop v1 endpoints\n", 0, 0, ""},
+		{"comments ignored", "# Please enter the commit message\nfix: handle empty branch\n# On branch main\n", 0, 0, ""},
+		{"merge skipped", "Merge branch 'main' into feature\n", 0, 0, ""},
+		{"fixup skipped", "fixup! feat: add thing\n", 0, 0, ""},
+		{"empty", "\n# only comments\n", 1, 1, "empty"},
+		{"unknown type", "feet(core): add thing\n", 1, 1, `type "feet" is not allowed`},
+		{"missing colon", "feat(core) add thing\n", 1, 11, `expected ": "`},
+		{"plain subject", "Add login page\n", 1, 4, `expected ": "`},
+		{"empty scope", "fix(): handle nil\n", 1, 4, "scope is empty"},
+		{"double space", "fix:  handle nil\n", 1, 5, "exactly one space"},
+		{"empty description", "fix: \n", 1, 5, "description after the type is empty"},
+		{"past tense", "fix: fixed nil branch\n", 1, 6, `"fix" instead of "fixed"`},
+		{"gerund", "feat: supporting tickets\n", 1, 7, `instead of "supporting"`},
+		{"trailing period", "docs: describe overrides.\n", 1, 25, "period"},
+		{"long subject", "feat: " + strings.Repeat("a", 70) + "\n", 1, 73, "76 characters long"},
+		{"no blank line", "feat: add thing\nmore detail\n", 2, 1, "blank line"},
+		{"long body", "feat: add thing\n\n" + strings.Repeat("word ", 16) + "\n", 3, 73, "wrap at 72"},
+	}
+	
+	for _, test := range tests {
+		problems, err := Lint(test.message, FormatConventional, settings)
+		if err != nil {
+			t.Fatalf("%s: Lint failed: %v", test.name, err)
+		}
+		
+		if test.problem == "" {
+			if len(problems) > 0 {
+				t.Errorf("%s: expected no problems, got %v", test.name, problems)
+			}
+			continue
+		}
+		
+		found := false
+		for _, p := range problems {
+			if strings.Contains(p.Message, test.problem) {
+				found = true
+				if p.Line != test.line || p.Column != test.column {
+					t.Errorf("%s: expected problem at %d:%d, got %s", test.name, test.line, test.column, p)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected problem containing %q, got %v", test.name, test.problem, problems)
+		}
+	}
+}
+
+func TestLintSettings(t *testing.T) {
+	settings := config.DefaultCommitLint()
+	settings.RequireScope = true
+	settings.Scopes = []string{"core", "cli"}
+	settings.TicketPattern = `[A-Z]+-[0-9]+`
+	
+	problems, err := Lint("feat(core): add thing\n\nRefs GS-42\n", FormatConventional, settings)
+	if err != nil || len(problems) > 0 {
+		t.Errorf("Expected valid message, got %v (%v)", problems, err)
+	}
+	
+	problems, _ = Lint("feat: add thing\n", FormatConventional, settings)
+	messages := make([]string, 0, len(problems))
+	for _, p := range problems {
+		messages = append(messages, p.Message)
+	}
+	joined := strings.Join(messages, "\n")
+	if !strings.Contains(joined, "scope is required") || !strings.Contains(joined, "missing ticket reference") {
+		t.Errorf("Expected scope and ticket problems, got %v", messages)
+	}
+	
+	problems, _ = Lint("feat(web): add thing GS-1\n", FormatConventional, settings)
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, `scope "web" is not allowed`) {
+		t.Errorf("Expected disallowed scope problem, got %v", problems)
+	}
+	if pointer := problems[0].Pointer(); !strings.HasSuffix(pointer, "\n     ^^^") {
+		t.Errorf("Pointer should underline the scope, got %q", pointer)
+	}
+	
+	problems, _ = Lint("feat(wéb): add thing GS-1\n", FormatConventional, settings)
+	if len(problems) != 1 || problems[0].Column != 6 {
+		t.Fatalf("Expected disallowed scope at column 6, got %v", problems)
+	}
+	if pointer := problems[0].Pointer(); !strings.HasSuffix(pointer, "\n     ^^^") {
+		t.Errorf("Pointer should underline a non-ASCII scope by characters, got %q", pointer)
+	}
+	
+	settings.Scopes = nil
+	problems, _ = Lint("feat(wéb):  add thing GS-1\n", FormatConventional, settings)
+	if len(problems) != 1 || problems[0].Column != 11 {
+		t.Errorf("Expected spacing problem at column 11 after a non-ASCII scope, got %v", problems)
+	}
+	
+	settings.TicketPattern = "("
+	if _, err := Lint("feat: add thing\n", FormatConventional, settings); err == nil {
+		t.Error("Invalid ticket pattern should be reported")
+	}
+}
+
+func TestLintSimple(t *testing.T) {
+	settings := config.DefaultCommitLint()
+	
+	if problems, _ := Lint("Add login page\n", FormatSimple, settings); len(problems) > 0 {
+		t.Errorf("Simple subject should be valid, got %v", problems)
+	}
+	
+	problems, _ := Lint("Added login page.\n", FormatSimple, settings)
+	if len(problems) != 2 {
+		t.Errorf("Expected mood and period problems, got %v", problems)
+	}
+}
@@ -0,0 +1,553 @@
+package message
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gitsentry/internal/git"
+)
+
+const (
+	FormatConventional = "conventional"
+	FormatSimple       = "simple"
+)
+
+const (
+	TypeFeat     = "feat"
+	TypeFix      = "fix"
+	TypeDocs     = "docs"
+	TypeTest     = "test"
+	TypeChore    = "chore"
+	TypeRefactor = "refactor"
+)
+
+const (
+	kindCode  = "code"
+	kindDocs  = "docs"
+	kindTest  = "test"
+	kindChore = "chore"
+)
+
+const (
+	maxBodySymbols  = 5
+	maxSummaryNames = 3
+	bodyIndent      = "  "
+)
+
+type Message struct {
+	Type    string
+	Scope   string
+	Summary string
+	Body    []string
+}
+
+type fileChange struct {
+	patch   git.FilePatch
+	kind    string
+	added   []string
+	removed []string
+	touched []string
+}
+
+var symbolPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^func\s+\(\s*(?:\w+\s+)?\*?(\w+)(?:\[[^\]]*\])?\s*\)\s*(\w+)`),
+	regexp.MustCompile(`^func\s+(\w+)`),
+	regexp.MustCompile(`^type\s+(\w+)`),
+	regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)`),
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+(\w+)`),
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*(\w+)`),
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+(\w+)\s*=\s*(?:async\s+)?(?:\([^)]*\)|\w+)\s*=>`),
+	regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?fn\s+(\w+)`),
+	regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|trait)\s+(\w+)`),
+	regexp.MustCompile(`^\s*(?:public|private|protected)?\s*(?:interface|enum)\s+(\w+)`),
+}
+
+var containerDirs = map[string]bool{
+	"internal": true,
+	"pkg":      true,
+	"src":      true,
+	"lib":      true,
+	"cmd":      true,
+	"app":      true,
+	"apps":     true,
+	"packages": true,
+}
+
+var choreFiles = map[string]bool{
+	"go.mod":            true,
+	"go.sum":            true,
+	"package.json":      true,
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"Cargo.toml":        true,
+	"Cargo.lock":        true,
+	"requirements.txt":  true,
+	"Pipfile":           true,
+	"Pipfile.lock":      true,
+	"pyproject.toml":    true,
+	"Makefile":          true,
+	"Dockerfile":        true,
+	".gitignore":        true,
+	".gitattributes":    true,
+	".dockerignore":     true,
+	".editorconfig":     true,
+	".gitsentry.yaml":   true,
+}
+
+func Draft(patches []git.FilePatch, maxSubjectLength int) (Message, error) {
+	if len(patches) == 0 {
+		return Message{}, fmt.Errorf("no changes to describe")
+	}
+	
+	changes := make([]fileChange, 0, len(patches))
+	for _, patch := range patches {
+		changes = append(changes, analyze(patch))
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].patch.Path < changes[j].patch.Path
+	})
+	
+	primary := primaryChanges(changes)
+	
+	msg := Message{
+		Type:  inferType(primary),
+		Scope: inferScope(primary),
+	}
+	if msg.Scope == msg.Type || msg.Scope == msg.Type+"s" {
+		msg.Scope = ""
+	}
+	msg.Summary = summarize(msg, primary, maxSubjectLength)
+	
+	for _, change := range changes {
+		msg.Body = append(msg.Body, bodyLine(change))
+	}
+	
+	return msg, nil
+}
+
+func (m Message) Header(format string) string {
+	if format == FormatSimple {
+		return capitalize(m.Summary)
+	}
+	
+	if m.Scope != "" {
+		return fmt.Sprintf("%s(%s): %s", m.Type, m.Scope, m.Summary)
+	}
+	
+	return fmt.Sprintf("%s: %s", m.Type, m.Summary)
+}
+
+func (m Message) Format(format string, width int) string {
+	var sb strings.Builder
+	sb.WriteString(m.Header(format) + "\n")
+	
+	if len(m.Body) > 0 {
+		sb.WriteString("\n")
+		for _, line := range m.Body {
+			sb.WriteString(wrap(line, width) + "\n")
+		}
+	}
+	
+	return sb.String()
+}
+
+func analyze(patch git.FilePatch) fileChange {
+	change := fileChange{patch: patch, kind: classify(patch.Path)}
+	
+	seen := make(map[string]bool)
+	touch := func(symbol string) {
+		if symbol != "" && !seen[symbol] {
+			seen[symbol] = true
+			change.touched = append(change.touched, symbol)
+		}
+	}
+	
+	for _, hunk := range patch.Hunks {
+		declares := false
+		for _, line := range hunk.Removed {
+			if symbol := declaredSymbol(line); symbol != "" {
+				change.removed = append(change.removed, symbol)
+				touch(symbol)
+				declares = true
+			}
+		}
+		for _, line := range hunk.Added {
+			if symbol := declaredSymbol(line); symbol != "" {
+				change.added = append(change.added, symbol)
+				touch(symbol)
+				declares = true
+			}
+		}
+		
+		if !declares {
+			touch(declaredSymbol(hunk.Context))
+		}
+	}
+	
+	return change
+}
+
+func (c fileChange) newSymbols() []string {
+	return difference(c.added, c.removed)
+}
+
+func (c fileChange) goneSymbols() []string {
+	return difference(c.removed, c.added)
+}
+
+func classify(filePath string) string {
+	base := path.Base(filePath)
+	lower := strings.ToLower(base)
+	dirs := strings.Split(path.Dir(filePath), "/")
+	
+	for _, dir := range dirs {
+		switch dir {
+		case "test", "tests", "testdata", "__tests__", "spec":
+			return kindTest
+		}
+	}
+	if strings.HasSuffix(lower, "_test.go") || strings.HasSuffix(lower, "_test.py") || strings.HasPrefix(lower, "test_") ||
+		strings.Contains(lower, ".test.") || strings.Contains(lower, ".spec.") {
+		return kindTest
+	}
+	
+	if choreFiles[base] || dirs[0] == ".github" || dirs[0] == ".circleci" || dirs[0] == "scripts" {
+		return kindChore
+	}
+	switch path.Ext(lower) {
+	case ".yml", ".yaml", ".toml", ".lock":
+		return kindChore
+	}
+	
+	for _, dir := range dirs {
+		if dir == "docs" || dir == "doc" {
+			return kindDocs
+		}
+	}
+	switch path.Ext(lower) {
+	case ".md", ".markdown", ".rst", ".adoc", ".txt":
+		return kindDocs
+	}
+	for _, prefix := range []string{"readme", "changelog", "license", "contributing", "authors"} {
+		if strings.HasPrefix(lower, prefix) {
+			return kindDocs
+		}
+	}
+	
+	return kindCode
+}
+
+func declaredSymbol(line string) string {
+	for _, pattern := range symbolPatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		
+		if len(match) == 3 {
+			return match[1] + "." + match[2]
+		}
+		return match[1]
+	}
+	
+	return ""
+}
+
+func primaryChanges(changes []fileChange) []fileChange {
+	var code []fileChange
+	for _, change := range changes {
+		if change.kind == kindCode {
+			code = append(code, change)
+		}
+	}
+	if len(code) > 0 {
+		return code
+	}
+	
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.kind]++
+	}
+	
+	dominant := ""
+	for _, kind := range []string{kindTest, kindDocs, kindChore} {
+		if counts[kind] > counts[dominant] {
+			dominant = kind
+		}
+	}
+	
+	var primary []fileChange
+	for _, change := range changes {
+		if change.kind == dominant {
+			primary = append(primary, change)
+		}
+	}
+	
+	return primary
+}
+
+func inferType(primary []fileChange) string {
+	switch primary[0].kind {
+	case kindDocs:
+		return TypeDocs
+	case kindTest:
+		return TypeTest
+	case kindChore:
+		return TypeChore
+	}
+	
+	added, removed := 0, 0
+	restructured := false
+	for _, change := range primary {
+		if change.patch.Status == git.StatusAdded || len(change.newSymbols()) > 0 {
+			return TypeFeat
+		}
+		if change.patch.Status == git.StatusRenamed || change.patch.Status == git.StatusDeleted || len(change.goneSymbols()) > 0 {
+			restructured = true
+		}
+		added += change.patch.Added
+		removed += change.patch.Removed
+	}
+	
+	if restructured || (removed > added && added > 0) {
+		return TypeRefactor
+	}
+	
+	return TypeFix
+}
+
+func inferScope(primary []fileChange) string {
+	scope := ""
+	for i, change := range primary {
+		s := scopeOf(change.patch.Path)
+		if s == "" || (i > 0 && s != scope) {
+			return ""
+		}
+		scope = s
+	}
+	
+	return scope
+}
+
+func scopeOf(filePath string) string {
+	parts := strings.Split(filePath, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	
+	if containerDirs[parts[0]] && len(parts) > 2 {
+		return parts[1]
+	}
+	
+	return parts[0]
+}
+
+func summarize(msg Message, primary []fileChange, maxLength int) string {
+	verb := fileVerb(primary)
+	var names []string
+	
+	switch msg.Type {
+	case TypeFeat:
+		verb = "add"
+		for _, change := range primary {
+			names = append(names, change.newSymbols()...)
+		}
+		if added := fileNames(primary, git.StatusAdded); len(names) == 0 || (len(names) > maxSummaryNames && len(added) > 0) {
+			names = added
+		}
+	case TypeRefactor:
+		if renamed := renames(primary); len(renamed) > 0 && len(renamed) == len(primary) {
+			verb = "rename"
+			names = renamed
+			break
+		}
+		added := 0
+		for _, change := range primary {
+			added += change.patch.Added
+			names = append(names, change.goneSymbols()...)
+		}
+		if added == 0 && len(names) > 0 {
+			verb = "remove"
+			break
+		}
+		
+		names = nil
+		if verb == "update" {
+			verb = "rework"
+		}
+		for _, change := range primary {
+			names = append(names, change.touched...)
+		}
+	case TypeFix:
+		verb = "fix"
+		for _, change := range primary {
+			names = append(names, change.touched...)
+		}
+	case TypeTest:
+		for _, change := range primary {
+			names = append(names, change.newSymbols()...)
+		}
+		if len(names) > 0 {
+			verb = "add"
+		}
+	}
+	
+	if len(names) == 0 {
+		names = fileNames(primary, "")
+	}
+	
+	names = unique(names)
+	prefix := len(msg.Header(FormatConventional))
+	for shown := 2; shown >= 1; shown-- {
+		summary := verb + " " + listNames(names, shown)
+		if maxLength <= 0 || prefix+len(summary) <= maxLength {
+			return summary
+		}
+	}
+	
+	if len(primary) == 1 {
+		return verb + " 1 file"
+	}
+	
+	return fmt.Sprintf("%s %d files", verb, len(primary))
+}
+
+func fileVerb(changes []fileChange) string {
+	added, deleted := true, true
+	for _, change := range changes {
+		added = added && change.patch.Status == git.StatusAdded
+		deleted = deleted && change.patch.Status == git.StatusDeleted
+	}
+	
+	switch {
+	case added:
+		return "add"
+	case deleted:
+		return "remove"
+	}
+	
+	return "update"
+}
+
+func fileNames(changes []fileChange, status git.FileStatus) []string {
+	var names []string
+	for _, change := range changes {
+		if status == "" || change.patch.Status == status {
+			names = append(names, path.Base(change.patch.Path))
+		}
+	}
+	
+	return names
+}
+
+func renames(changes []fileChange) []string {
+	var names []string
+	for _, change := range changes {
+		if change.patch.Status == git.StatusRenamed {
+			names = append(names, path.Base(change.patch.OldPath)+" to "+path.Base(change.patch.Path))
+		}
+	}
+	
+	return names
+}
+
+func listNames(names []string, shown int) string {
+	switch {
+	case len(names) == 1:
+		return names[0]
+	case len(names) == 2 && shown >= 2:
+		return names[0] + " and " + names[1]
+	}
+	
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:shown], ", "), len(names)-shown)
+}
+
+func bodyLine(change fileChange) string {
+	line := "- " + change.patch.Path
+	
+	switch {
+	case change.patch.Binary:
+		line += " (binary)"
+	case change.patch.Status == git.StatusAdded:
+		line += " (new)"
+	case change.patch.Status == git.StatusDeleted:
+		line += " (deleted)"
+	case change.patch.Status == git.StatusRenamed:
+		line += " (renamed from " + change.patch.OldPath + ")"
+	}
+	
+	symbols := change.touched
+	if len(symbols) == 0 {
+		return line
+	}
+	
+	if len(symbols) > maxBodySymbols {
+		return fmt.Sprintf("%s: %s and %d more", line, strings.Join(symbols[:maxBodySymbols], ", "), len(symbols)-maxBodySymbols)
+	}
+	
+	return line + ": " + strings.Join(symbols, ", ")
+}
+
+func wrap(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = bodyIndent + word
+		}
+	}
+	
+	return strings.Join(append(lines, current), "\n")
+}
+
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, item := range b {
+		exclude[item] = true
+	}
+	
+	var result []string
+	for _, item := range a {
+		if !exclude[item] {
+			result = append(result, item)
+		}
+	}
+	
+	return result
+}
+
+func unique(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	var result []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	
+	return result
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	
+	return strings.ToUpper(s[:1]) + s[1:]
+}
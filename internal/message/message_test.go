@@ -0,0 +1,121 @@
+package message
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/git"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func TestDraftGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.diff"))
+	if err != nil || len(inputs) == 0 {
+		t.Fatalf("Failed to find golden inputs: %v", err)
+	}
+	
+	for _, input := range inputs {
+		data, err := os.ReadFile(input)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", input, err)
+		}
+		
+		msg, err := Draft(git.ParsePatch(data), 72)
+		if err != nil {
+			t.Errorf("Draft failed for %s: %v", input, err)
+			continue
+		}
+		
+		for _, format := range []string{FormatConventional, FormatSimple} {
+			golden := strings.TrimSuffix(input, ".diff") + "." + format + ".golden"
+			got := msg.Format(format, config.DefaultCommitLint().MaxBodyLineLength)
+			
+			if *update {
+				os.WriteFile(golden, []byte(got), 0644)
+				continue
+			}
+			
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", golden, err)
+			}
+			
+			if got != string(want) {
+				t.Errorf("%s mismatch:\n--- got\n%s--- want\n%s", golden, got, want)
+			}
+		}
+	}
+}
+
+func TestDraftRejectsCleanTree(t *testing.T) {
+	if _, err := Draft(nil, 72); err == nil {
+		t.Error("Draft should fail without changes")
+	}
+}
+
+func TestSummaryLength(t *testing.T) {
+	var patches []git.FilePatch
+	for _, name := range []string{"a_very_long_file_name_number_one.go", "another_really_long_file_name.go", "third.go"} {
+		patches = append(patches, git.FilePatch{Path: "internal/subsystem/" + name, Status: git.StatusAdded})
+	}
+	
+	for _, max := range []int{72, 50} {
+		msg, err := Draft(patches, max)
+		if err != nil {
+			t.Fatalf("Draft failed: %v", err)
+		}
+		
+		if header := msg.Header(FormatConventional); len(header) > max {
+			t.Errorf("Header should fit in %d characters, got %q", max, header)
+		}
+	}
+}
+
+func TestSummaryFallbackCount(t *testing.T) {
+	patches := []git.FilePatch{{Path: "internal/subsystem/" + strings.Repeat("a_really_long_generated_name_", 3) + ".go", Status: git.StatusAdded}}
+	
+	msg, err := Draft(patches, 72)
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+	
+	if msg.Summary != "add 1 file" {
+		t.Errorf("Single file fallback should be singular, got %q", msg.Summary)
+	}
+}
+
+func TestDraftPassesLint(t *testing.T) {
+	var diff strings.Builder
+	diff.WriteString("diff --git a/internal/handlers/registration.go b/internal/handlers/registration.go\n")
+	diff.WriteString("--- a/internal/handlers/registration.go\n+++ b/internal/handlers/registration.go\n")
+	diff.WriteString("@@ -1,0 +1,7 @@\n")
+	for _, name := range []string{"ValidateRegistrationRequest", "NormalizeEmailAddress", "HashPasswordWithPepper", "PersistPendingRegistration", "SendVerificationEmail", "ScheduleReminder", "AuditTrail"} {
+		diff.WriteString("+func " + name + "() {}\n")
+	}
+	
+	msg, err := Draft(git.ParsePatch([]byte(diff.String())), 72)
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+	
+	for _, format := range []string{FormatConventional, FormatSimple} {
+		settings := config.DefaultCommitLint()
+		for _, width := range []int{72, 50} {
+			settings.MaxBodyLineLength = width
+			text := msg.Format(format, width)
+			
+			problems, err := Lint(text, format, settings)
+			if err != nil || len(problems) > 0 {
+				t.Errorf("Draft should pass lint at width %d, got %v (%v):\n%s", width, problems, err, text)
+			}
+			if !strings.Contains(text, "\n"+bodyIndent) {
+				t.Errorf("Long body lines should wrap with an indent at width %d:\n%s", width, text)
+			}
+		}
+	}
+}
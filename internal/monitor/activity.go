@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"sync"
+	"time"
+)
+
+type ActivityStats struct {
+	InBurst       bool
+	BurstStart    time.Time
+	BurstEvents   int
+	BurstDuration time.Duration
+	Idle          time.Duration
+	LastGap       time.Duration
+}
+
+type Activity struct {
+	mu          sync.Mutex
+	burstGap    time.Duration
+	lastEvent   time.Time
+	burstStart  time.Time
+	burstEvents int
+	lastGap     time.Duration
+}
+
+func NewActivity(burstGap time.Duration) *Activity {
+	return &Activity{burstGap: burstGap}
+}
+
+func (a *Activity) SetBurstGap(burstGap time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	
+	a.burstGap = burstGap
+}
+
+func (a *Activity) Record(at time.Time, events int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	
+	if a.lastEvent.IsZero() || at.Sub(a.lastEvent) >= a.burstGap {
+		if !a.lastEvent.IsZero() {
+			a.lastGap = at.Sub(a.lastEvent)
+		}
+		a.burstStart = at
+		a.burstEvents = 0
+	}
+	
+	a.lastEvent = at
+	a.burstEvents += events
+}
+
+func (a *Activity) Stats(now time.Time) ActivityStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	
+	if a.lastEvent.IsZero() {
+		return ActivityStats{}
+	}
+	
+	idle := now.Sub(a.lastEvent)
+	
+	return ActivityStats{
+		InBurst:       idle < a.burstGap,
+		BurstStart:    a.burstStart,
+		BurstEvents:   a.burstEvents,
+		BurstDuration: a.lastEvent.Sub(a.burstStart),
+		Idle:          idle,
+		LastGap:       a.lastGap,
+	}
+}
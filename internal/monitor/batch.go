@@ -0,0 +1,208 @@
+package monitor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type BatchKind string
+
+const (
+	BatchEdit              BatchKind = "edit"
+	BatchBurst             BatchKind = "burst"
+	BatchBranchSwitch      BatchKind = "branch_switch"
+	BatchRebase            BatchKind = "rebase"
+	BatchDependencyInstall BatchKind = "dependency_install"
+)
+
+const (
+	DefaultDebounce       = 300 * time.Millisecond
+	DefaultBurstThreshold = 200
+)
+
+var dependencyDirs = map[string]bool{
+	"node_modules":     true,
+	"bower_components": true,
+	"vendor":           true,
+	".venv":            true,
+	"venv":             true,
+	"site-packages":    true,
+	".bundle":          true,
+}
+
+var gitOperationMarkers = []string{
+	"rebase-merge",
+	"rebase-apply",
+	"MERGE_HEAD",
+	"CHERRY_PICK_HEAD",
+	"REVERT_HEAD",
+}
+
+type Options struct {
+	Debounce       time.Duration
+	BurstThreshold int
+	Budget         *Budget
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Debounce:       DefaultDebounce,
+		BurstThreshold: DefaultBurstThreshold,
+	}
+}
+
+type Change struct {
+	Path string
+	Op   fsnotify.Op
+}
+
+type Batch struct {
+	Changes []Change
+	Events  int
+	Kind    BatchKind
+}
+
+func (b Batch) Paths() []string {
+	paths := make([]string, len(b.Changes))
+	for i, change := range b.Changes {
+		paths[i] = change.Path
+	}
+	
+	return paths
+}
+
+func (b Batch) IsGitOperation() bool {
+	return b.Kind == BatchBranchSwitch || b.Kind == BatchRebase
+}
+
+type batcher struct {
+	root      string
+	opts      Options
+	pending   map[string]*Change
+	events    int
+	startHead []byte
+	started   time.Time
+}
+
+func newBatcher(root string, opts Options) *batcher {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	if opts.BurstThreshold <= 0 {
+		opts.BurstThreshold = DefaultBurstThreshold
+	}
+	
+	return &batcher{
+		root:    root,
+		opts:    opts,
+		pending: make(map[string]*Change),
+	}
+}
+
+func (b *batcher) add(path string, op fsnotify.Op) {
+	if len(b.pending) == 0 && b.events == 0 {
+		b.startHead = b.readHead()
+		b.started = time.Now()
+	}
+	
+	b.events++
+	if change, ok := b.pending[path]; ok {
+		change.Op |= op
+		return
+	}
+	
+	b.pending[path] = &Change{Path: path, Op: op}
+}
+
+func (b *batcher) empty() bool {
+	return b.events == 0
+}
+
+func (b *batcher) overdue() bool {
+	return !b.empty() && time.Since(b.started) >= b.maxDelay()
+}
+
+func (b *batcher) maxDelay() time.Duration {
+	return 10 * b.opts.Debounce
+}
+
+func (b *batcher) flush() Batch {
+	batch := Batch{
+		Changes: make([]Change, 0, len(b.pending)),
+		Events:  b.events,
+	}
+	
+	for _, change := range b.pending {
+		batch.Changes = append(batch.Changes, *change)
+	}
+	sort.Slice(batch.Changes, func(i, j int) bool {
+		return batch.Changes[i].Path < batch.Changes[j].Path
+	})
+	
+	batch.Kind = b.classify(batch)
+	
+	b.pending = make(map[string]*Change)
+	b.events = 0
+	b.startHead = nil
+	
+	return batch
+}
+
+func (b *batcher) classify(batch Batch) BatchKind {
+	gitDir := filepath.Join(b.root, ".git")
+	for _, marker := range gitOperationMarkers {
+		if _, err := os.Stat(filepath.Join(gitDir, marker)); err == nil {
+			return BatchRebase
+		}
+	}
+	
+	if b.startHead != nil && !bytes.Equal(b.startHead, b.readHead()) {
+		return BatchBranchSwitch
+	}
+	
+	dependencyChanges := 0
+	for _, change := range batch.Changes {
+		if b.isDependencyPath(change.Path) {
+			dependencyChanges++
+		}
+	}
+	if dependencyChanges > 0 && dependencyChanges*2 >= len(batch.Changes) {
+		return BatchDependencyInstall
+	}
+	
+	if len(batch.Changes) >= b.opts.BurstThreshold {
+		return BatchBurst
+	}
+	
+	return BatchEdit
+}
+
+func (b *batcher) isDependencyPath(path string) bool {
+	rel, err := filepath.Rel(b.root, path)
+	if err != nil {
+		rel = path
+	}
+	
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if dependencyDirs[part] {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func (b *batcher) readHead() []byte {
+	data, err := os.ReadFile(filepath.Join(b.root, ".git", "HEAD"))
+	if err != nil {
+		return []byte{}
+	}
+	
+	return data
+}
@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	defaultWatchLimit = 8192
+	maxUserWatches    = "/proc/sys/fs/inotify/max_user_watches"
+)
+
+type Budget struct {
+	mu    sync.Mutex
+	limit int
+	used  int
+}
+
+func NewBudget(limit int) *Budget {
+	return &Budget{limit: limit}
+}
+
+func DefaultBudget() *Budget {
+	limit := defaultWatchLimit
+	
+	if data, err := os.ReadFile(maxUserWatches); err == nil {
+		if max, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && max > 0 {
+			limit = max / 2
+		}
+	}
+	
+	return NewBudget(limit)
+}
+
+func (b *Budget) acquire() bool {
+	if b == nil {
+		return true
+	}
+	
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	if b.used >= b.limit {
+		return false
+	}
+	
+	b.used++
+	return true
+}
+
+func (b *Budget) release(n int) {
+	if b == nil {
+		return
+	}
+	
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	b.used -= n
+	if b.used < 0 {
+		b.used = 0
+	}
+}
+
+func (b *Budget) Usage() (int, int) {
+	if b == nil {
+		return 0, 0
+	}
+	
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	return b.used, b.limit
+}
+
+type watchSet struct {
+	watcher *fsnotify.Watcher
+	budget  *Budget
+	mu      sync.Mutex
+	watched map[string]bool
+}
+
+func newWatchSet(watcher *fsnotify.Watcher, budget *Budget) *watchSet {
+	return &watchSet{
+		watcher: watcher,
+		budget:  budget,
+		watched: make(map[string]bool),
+	}
+}
+
+func (w *watchSet) add(dir string) error {
+	dir = filepath.Clean(dir)
+	
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	
+	if w.watched[dir] {
+		return nil
+	}
+	
+	if !w.budget.acquire() {
+		return fmt.Errorf("file watch budget exhausted")
+	}
+	
+	if err := w.watcher.Add(dir); err != nil {
+		w.budget.release(1)
+		return err
+	}
+	w.watched[dir] = true
+	
+	return nil
+}
+
+func (w *watchSet) remove(path string) {
+	path = filepath.Clean(path)
+	prefix := path + string(filepath.Separator)
+	
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	
+	for dir := range w.watched {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			w.watcher.Remove(dir)
+			delete(w.watched, dir)
+			w.budget.release(1)
+		}
+	}
+}
+
+func (w *watchSet) len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	
+	return len(w.watched)
+}
+
+func (w *watchSet) close() {
+	w.watcher.Close()
+	
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	
+	w.budget.release(len(w.watched))
+	w.watched = make(map[string]bool)
+}
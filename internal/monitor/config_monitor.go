@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const configSettleDelay = 200 * time.Millisecond
+
+type ConfigMonitor struct {
+	watcher  *fsnotify.Watcher
+	watches  *watchSet
+	files    map[string]bool
+	callback func()
+	done     chan bool
+	mu       sync.Mutex
+	timer    *time.Timer
+	stopped  bool
+}
+
+func NewConfigMonitor(paths []string, budget *Budget, callback func()) (*ConfigMonitor, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	
+	cm := &ConfigMonitor{
+		watcher:  watcher,
+		watches:  newWatchSet(watcher, budget),
+		files:    make(map[string]bool),
+		callback: callback,
+		done:     make(chan bool),
+	}
+	
+	dirs := make(map[string]bool)
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		cm.files[path] = true
+		dirs[filepath.Dir(path)] = true
+	}
+	
+	for dir := range dirs {
+		cm.watches.add(dir)
+	}
+	
+	if cm.watches.len() == 0 {
+		cm.watches.close()
+		return nil, fmt.Errorf("no config directory could be watched")
+	}
+	
+	go cm.watch()
+	
+	return cm, nil
+}
+
+func (cm *ConfigMonitor) watch() {
+	for {
+		select {
+		case event, ok := <-cm.watcher.Events:
+			if !ok {
+				return
+			}
+			
+			if event.Op == fsnotify.Chmod || !cm.files[filepath.Clean(event.Name)] {
+				continue
+			}
+			cm.schedule()
+			
+		case _, ok := <-cm.watcher.Errors:
+			if !ok {
+				return
+			}
+			
+		case <-cm.done:
+			return
+		}
+	}
+}
+
+func (cm *ConfigMonitor) schedule() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	
+	if cm.stopped {
+		return
+	}
+	
+	if cm.timer != nil {
+		cm.timer.Stop()
+	}
+	cm.timer = time.AfterFunc(configSettleDelay, cm.callback)
+}
+
+func (cm *ConfigMonitor) Stop() {
+	cm.mu.Lock()
+	cm.stopped = true
+	if cm.timer != nil {
+		cm.timer.Stop()
+	}
+	cm.mu.Unlock()
+	
+	close(cm.done)
+	cm.watches.close()
+}
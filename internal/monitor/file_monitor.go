@@ -1,33 +1,63 @@
 package monitor
 
 import (
+	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"gitsentry/internal/ignore"
 	"gitsentry/internal/security"
 )
 
 type FileMonitor struct {
-	watcher  *fsnotify.Watcher
-	callback func(string)
-	done     chan bool
+	watcher   *fsnotify.Watcher
+	callback  func(Batch)
+	done      chan bool
+	matcher   *ignore.Matcher
+	batcher   *batcher
+	budget    *Budget
+	mu        sync.Mutex
+	watched   map[string]bool
+	unwatched map[string]bool
 }
 
-func NewFileMonitor(path string, callback func(string)) (*FileMonitor, error) {
+func NewFileMonitor(path string, opts Options, callback func(Batch)) (*FileMonitor, error) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, err
 	}
 	
 	monitor := &FileMonitor{
-		watcher:  watcher,
-		callback: callback,
-		done:     make(chan bool),
+		watcher:   watcher,
+		callback:  callback,
+		done:      make(chan bool),
+		matcher:   ignore.NewMatcher(path),
+		batcher:   newBatcher(path, opts),
+		budget:    opts.Budget,
+		watched:   make(map[string]bool),
+		unwatched: make(map[string]bool),
 	}
 	
-	err = watcher.Add(path)
-	if err != nil {
+	if !opts.Budget.acquire() {
+		watcher.Close()
+		return nil, fmt.Errorf("file watch budget exhausted")
+	}
+	
+	if err := watcher.Add(path); err != nil {
+		opts.Budget.release(1)
+		watcher.Close()
+		return nil, err
+	}
+	monitor.watched[filepath.Clean(path)] = true
+	
+	if err := monitor.addTree(path); err != nil {
+		watcher.Close()
+		monitor.budget.release(len(monitor.watched))
 		return nil, err
 	}
 	
@@ -36,7 +66,133 @@ func NewFileMonitor(path string, callback func(string)) (*FileMonitor, error) {
 	return monitor, nil
 }
 
+func (fm *FileMonitor) addTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		
+		if !d.IsDir() || path == root {
+			return nil
+		}
+		
+		if fm.shouldIgnore(path) {
+			return filepath.SkipDir
+		}
+		
+		fm.addWatch(path)
+		return nil
+	})
+}
+
+func (fm *FileMonitor) addNewTree(root string) bool {
+	found := false
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		
+		if path != root && fm.shouldIgnore(path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		
+		if d.IsDir() {
+			fm.addWatch(path)
+			return nil
+		}
+		
+		if security.ValidateFilePath(path) == nil {
+			fm.batcher.add(path, fsnotify.Create)
+			found = true
+		}
+		return nil
+	})
+	
+	return found
+}
+
+func (fm *FileMonitor) addWatch(dir string) {
+	dir = filepath.Clean(dir)
+	
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	
+	if fm.watched[dir] {
+		return
+	}
+	
+	if !fm.budget.acquire() {
+		fm.unwatched[dir] = true
+		return
+	}
+	
+	if err := fm.watcher.Add(dir); err != nil {
+		fm.budget.release(1)
+		return
+	}
+	fm.watched[dir] = true
+	delete(fm.unwatched, dir)
+}
+
+func (fm *FileMonitor) removeWatch(path string) {
+	path = filepath.Clean(path)
+	prefix := path + string(filepath.Separator)
+	
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	
+	for dir := range fm.watched {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			fm.watcher.Remove(dir)
+			delete(fm.watched, dir)
+			fm.budget.release(1)
+		}
+	}
+	
+	for dir := range fm.unwatched {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			delete(fm.unwatched, dir)
+		}
+	}
+}
+
+func (fm *FileMonitor) isWatched(path string) bool {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	
+	return fm.watched[filepath.Clean(path)]
+}
+
+func (fm *FileMonitor) WatchedDirs() []string {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	
+	dirs := make([]string, 0, len(fm.watched))
+	for dir := range fm.watched {
+		dirs = append(dirs, dir)
+	}
+	
+	return dirs
+}
+
+func (fm *FileMonitor) UnwatchedDirs() int {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	
+	return len(fm.unwatched)
+}
+
 func (fm *FileMonitor) watch() {
+	timer := time.NewTimer(fm.batcher.opts.Debounce)
+	timer.Stop()
+	defer timer.Stop()
+	
 	for {
 		select {
 		case event, ok := <-fm.watcher.Events:
@@ -44,18 +200,19 @@ func (fm *FileMonitor) watch() {
 				return
 			}
 			
-			if fm.shouldIgnore(event.Name) {
+			if !fm.handleEvent(event) {
 				continue
 			}
 			
-			if err := security.ValidateFilePath(event.Name); err != nil {
+			if fm.batcher.overdue() {
+				fm.flush()
 				continue
 			}
 			
-			if event.Op&fsnotify.Write == fsnotify.Write || 
-			   event.Op&fsnotify.Create == fsnotify.Create {
-				fm.callback(event.Name)
-			}
+			resetTimer(timer, fm.batcher.opts.Debounce)
+			
+		case <-timer.C:
+			fm.flush()
 			
 		case _, ok := <-fm.watcher.Errors:
 			if !ok {
@@ -68,51 +225,82 @@ func (fm *FileMonitor) watch() {
 	}
 }
 
-func (fm *FileMonitor) shouldIgnore(path string) bool {
-	if strings.Contains(path, "/.") {
-		return true
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
+func (fm *FileMonitor) handleEvent(event fsnotify.Event) bool {
+	if isIgnoreFile(event.Name) {
+		fm.matcher.Invalidate(filepath.Dir(event.Name))
+		fm.addTree(filepath.Dir(event.Name))
 	}
 	
-	ignoreDirs := []string{
-		"node_modules",
-		".git",
-		".gitsentry",
-		"vendor",
-		"target",
-		"build",
-		"dist",
-		".cache",
-		"tmp",
-		"temp",
+	if fm.shouldIgnore(event.Name) {
+		return false
 	}
 	
-	for _, dir := range ignoreDirs {
-		if strings.Contains(path, dir) {
-			return true
+	if err := security.ValidateFilePath(event.Name); err != nil {
+		return false
+	}
+	
+	if event.Op&fsnotify.Remove == fsnotify.Remove ||
+		event.Op&fsnotify.Rename == fsnotify.Rename {
+		if fm.isWatched(event.Name) {
+			fm.removeWatch(event.Name)
+			return false
 		}
+		fm.batcher.add(event.Name, event.Op)
+		return true
 	}
 	
-	ignoreExts := []string{
-		".tmp",
-		".temp",
-		".swp",
-		".swo",
-		".log",
-		".lock",
-		"~",
+	if event.Op&fsnotify.Create == fsnotify.Create && isDir(event.Name) {
+		return fm.addNewTree(event.Name)
 	}
 	
-	ext := filepath.Ext(path)
-	for _, ignoreExt := range ignoreExts {
-		if ext == ignoreExt || strings.HasSuffix(path, ignoreExt) {
-			return true
-		}
+	if event.Op&fsnotify.Write == fsnotify.Write || 
+	   event.Op&fsnotify.Create == fsnotify.Create {
+		fm.batcher.add(event.Name, event.Op)
+		return true
 	}
 	
 	return false
 }
 
+func (fm *FileMonitor) flush() {
+	if fm.batcher.empty() {
+		return
+	}
+	
+	fm.callback(fm.batcher.flush())
+}
+
+func isIgnoreFile(path string) bool {
+	base := filepath.Base(path)
+	return base == ignore.GitIgnoreFile || base == ignore.GitSentryIgnoreFile
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func (fm *FileMonitor) shouldIgnore(path string) bool {
+	return fm.matcher.Match(path, isDir(path) || fm.isWatched(path))
+}
+
 func (fm *FileMonitor) Stop() {
 	close(fm.done)
 	fm.watcher.Close()
+	
+	fm.mu.Lock()
+	fm.budget.release(len(fm.watched))
+	fm.watched = make(map[string]bool)
+	fm.unwatched = make(map[string]bool)
+	fm.mu.Unlock()
 }
\ No newline at end of file
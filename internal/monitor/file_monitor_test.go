@@ -0,0 +1,396 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestRecursiveWatch(t *testing.T) {
+	tempDir := "test_recursive"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	os.MkdirAll(filepath.Join(tempDir, "src", "pkg"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "node_modules", "dep"), 0755)
+	os.WriteFile(filepath.Join(tempDir, ".gitignore"), []byte("node_modules/\n"), 0644)
+	
+	changes := make(chan string, 10)
+	fm, err := NewFileMonitor(tempDir, testOptions(), func(batch Batch) {
+		for _, path := range batch.Paths() {
+			changes <- path
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to create monitor: %v", err)
+	}
+	defer fm.Stop()
+	
+	watched := make(map[string]bool)
+	for _, dir := range fm.WatchedDirs() {
+		watched[dir] = true
+	}
+	
+	if !watched[filepath.Join(tempDir, "src", "pkg")] {
+		t.Error("Nested directory should be watched")
+	}
+	
+	if watched[filepath.Join(tempDir, "node_modules", "dep")] {
+		t.Error("Ignored directory should not be watched")
+	}
+	
+	target := filepath.Join(tempDir, "src", "pkg", "foo.go")
+	os.WriteFile(target, []byte("package pkg\n"), 0644)
+	
+	if !waitForChange(changes, target) {
+		t.Error("Expected change event for nested file")
+	}
+}
+
+func TestWatchNewAndRemovedDirectories(t *testing.T) {
+	tempDir := "test_new_dirs"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	changes := make(chan string, 10)
+	fm, err := NewFileMonitor(tempDir, testOptions(), func(batch Batch) {
+		for _, path := range batch.Paths() {
+			changes <- path
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to create monitor: %v", err)
+	}
+	defer fm.Stop()
+	
+	newDir := filepath.Join(tempDir, "feature")
+	os.MkdirAll(newDir, 0755)
+	
+	deadline := time.Now().Add(2 * time.Second)
+	for !fm.isWatched(newDir) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !fm.isWatched(newDir) {
+		t.Fatal("New directory should be watched")
+	}
+	
+	target := filepath.Join(newDir, "new.go")
+	os.WriteFile(target, []byte("package feature\n"), 0644)
+	
+	if !waitForChange(changes, target) {
+		t.Error("Expected change event in new directory")
+	}
+	
+	os.RemoveAll(newDir)
+	
+	deadline = time.Now().Add(2 * time.Second)
+	for fm.isWatched(newDir) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if fm.isWatched(newDir) {
+		t.Error("Removed directory should no longer be watched")
+	}
+}
+
+func TestFilesInNewDirectoryAreReported(t *testing.T) {
+	tempDir := "test_new_dir_files"
+	os.MkdirAll(tempDir, 0755)
+	os.MkdirAll(tempDir+"_staging/feature/nested", 0755)
+	defer os.RemoveAll(tempDir)
+	defer os.RemoveAll(tempDir + "_staging")
+	
+	os.WriteFile(filepath.Join(tempDir+"_staging", "feature", "a.go"), []byte("package feature\n"), 0644)
+	os.WriteFile(filepath.Join(tempDir+"_staging", "feature", "nested", "b.go"), []byte("package nested\n"), 0644)
+	
+	changes := make(chan string, 10)
+	fm, err := NewFileMonitor(tempDir, testOptions(), func(batch Batch) {
+		for _, path := range batch.Paths() {
+			changes <- path
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to create monitor: %v", err)
+	}
+	defer fm.Stop()
+	
+	if err := os.Rename(filepath.Join(tempDir+"_staging", "feature"), filepath.Join(tempDir, "feature")); err != nil {
+		t.Fatalf("Failed to move directory into place: %v", err)
+	}
+	
+	want := map[string]bool{
+		filepath.Join(tempDir, "feature", "a.go"):           true,
+		filepath.Join(tempDir, "feature", "nested", "b.go"): true,
+	}
+	timeout := time.After(2 * time.Second)
+	for len(want) > 0 {
+		select {
+		case path := <-changes:
+			delete(want, path)
+		case <-timeout:
+			t.Fatalf("Expected files of a new directory to be reported, missing %v", want)
+		}
+	}
+	
+	if !fm.isWatched(filepath.Join(tempDir, "feature", "nested")) {
+		t.Error("Nested directory of a new tree should be watched")
+	}
+}
+
+func TestDebounceCoalescesEvents(t *testing.T) {
+	tempDir := "test_debounce"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	batches := make(chan Batch, 10)
+	fm, err := NewFileMonitor(tempDir, testOptions(), func(batch Batch) {
+		batches <- batch
+	})
+	if err != nil {
+		t.Fatalf("Failed to create monitor: %v", err)
+	}
+	defer fm.Stop()
+	
+	target := filepath.Join(tempDir, "main.go")
+	for i := 0; i < 20; i++ {
+		os.WriteFile(target, []byte(fmt.Sprintf("package main // %d\n", i)), 0644)
+	}
+	
+	select {
+	case batch := <-batches:
+		if len(batch.Changes) != 1 {
+			t.Errorf("Expected 1 coalesced change, got %d", len(batch.Changes))
+		}
+		if batch.Events < 2 {
+			t.Errorf("Expected multiple raw events, got %d", batch.Events)
+		}
+		if batch.Kind != BatchEdit {
+			t.Errorf("Expected edit batch, got %s", batch.Kind)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a batch")
+	}
+	
+	select {
+	case batch := <-batches:
+		t.Errorf("Expected a single batch, got another with %d changes", len(batch.Changes))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestBatchClassification(t *testing.T) {
+	tempDir := "test_classify"
+	os.MkdirAll(filepath.Join(tempDir, ".git"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	headPath := filepath.Join(tempDir, ".git", "HEAD")
+	os.WriteFile(headPath, []byte("ref: refs/heads/main\n"), 0644)
+	
+	b := newBatcher(tempDir, Options{Debounce: 50 * time.Millisecond, BurstThreshold: 3})
+	
+	b.add(filepath.Join(tempDir, "main.go"), fsnotify.Write)
+	if kind := b.flush().Kind; kind != BatchEdit {
+		t.Errorf("Expected edit batch, got %s", kind)
+	}
+	
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		b.add(filepath.Join(tempDir, name), fsnotify.Write)
+	}
+	if kind := b.flush().Kind; kind != BatchBurst {
+		t.Errorf("Expected burst batch, got %s", kind)
+	}
+	
+	b.add(filepath.Join(tempDir, "vendor", "dep", "dep.go"), fsnotify.Create)
+	if kind := b.flush().Kind; kind != BatchDependencyInstall {
+		t.Errorf("Expected dependency install batch, got %s", kind)
+	}
+	
+	b.add(filepath.Join(tempDir, "main.go"), fsnotify.Write)
+	os.WriteFile(headPath, []byte("ref: refs/heads/feature\n"), 0644)
+	if kind := b.flush().Kind; kind != BatchBranchSwitch {
+		t.Errorf("Expected branch switch batch, got %s", kind)
+	}
+	
+	os.MkdirAll(filepath.Join(tempDir, ".git", "rebase-merge"), 0755)
+	b.add(filepath.Join(tempDir, "main.go"), fsnotify.Write)
+	if kind := b.flush().Kind; kind != BatchRebase {
+		t.Errorf("Expected rebase batch, got %s", kind)
+	}
+}
+
+func testOptions() Options {
+	return Options{Debounce: 50 * time.Millisecond, BurstThreshold: DefaultBurstThreshold}
+}
+
+func waitForChange(changes chan string, want string) bool {
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case path := <-changes:
+			if path == want {
+				return true
+			}
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+func TestRefMonitorDetectsCommit(t *testing.T) {
+	tempDir := "test_ref_monitor"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "GitSentry Test"},
+		{"config", "user.email", "test@gitsentry.local"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tempDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	
+	budget := NewBudget(100)
+	changes := make(chan string, 100)
+	rm, err := NewRefMonitor(filepath.Join(tempDir, ".git"), budget, func() {
+		changes <- "refs"
+	})
+	if err != nil {
+		t.Fatalf("Failed to create ref monitor: %v", err)
+	}
+	defer rm.Stop()
+	
+	if used, _ := budget.Usage(); used == 0 {
+		t.Error("Ref watches should draw from the watch budget")
+	}
+	
+	cmd := exec.Command("git", "commit", "-q", "--allow-empty", "-m", "second")
+	cmd.Dir = tempDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v\n%s", err, output)
+	}
+	
+	if !waitForChange(changes, "refs") {
+		t.Error("Expected ref change after commit")
+	}
+}
+
+func TestWatchBudget(t *testing.T) {
+	tempDir := "test_budget"
+	os.MkdirAll(filepath.Join(tempDir, "a"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "b"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "c"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	budget := NewBudget(3)
+	opts := testOptions()
+	opts.Budget = budget
+	
+	fm, err := NewFileMonitor(tempDir, opts, func(batch Batch) {})
+	if err != nil {
+		t.Fatalf("Failed to create monitor: %v", err)
+	}
+	
+	used, limit := budget.Usage()
+	if used != limit {
+		t.Errorf("Expected budget to be fully used, got %d/%d", used, limit)
+	}
+	
+	if len(fm.WatchedDirs()) != 3 {
+		t.Errorf("Expected 3 watched directories, got %d", len(fm.WatchedDirs()))
+	}
+	
+	if fm.UnwatchedDirs() != 1 {
+		t.Errorf("Expected 1 directory skipped for lack of budget, got %d", fm.UnwatchedDirs())
+	}
+	
+	if _, err := NewFileMonitor(tempDir, opts, func(batch Batch) {}); err == nil {
+		t.Error("Second monitor should fail when the budget is exhausted")
+	}
+	
+	fm.Stop()
+	
+	if used, _ := budget.Usage(); used != 0 {
+		t.Errorf("Stopping the monitor should release its watches, %d still used", used)
+	}
+}
+
+func TestActivityBursts(t *testing.T) {
+	activity := NewActivity(time.Minute)
+	start := time.Now()
+	
+	if activity.Stats(start).InBurst {
+		t.Error("No events should not count as a burst")
+	}
+	
+	activity.Record(start, 3)
+	activity.Record(start.Add(20*time.Second), 2)
+	
+	stats := activity.Stats(start.Add(30 * time.Second))
+	if !stats.InBurst || stats.BurstEvents != 5 || stats.BurstDuration != 20*time.Second {
+		t.Errorf("Unexpected burst stats: %+v", stats)
+	}
+	
+	stats = activity.Stats(start.Add(90 * time.Second))
+	if stats.InBurst || stats.Idle != 70*time.Second {
+		t.Errorf("Expected idle gap after burst, got %+v", stats)
+	}
+	
+	activity.Record(start.Add(5*time.Minute), 1)
+	stats = activity.Stats(start.Add(5 * time.Minute))
+	if stats.BurstEvents != 1 || stats.LastGap != 280*time.Second {
+		t.Errorf("New burst should start after an idle gap, got %+v", stats)
+	}
+}
+
+func TestAuxiliaryMonitorsReleaseBudget(t *testing.T) {
+	tempDir := "test_aux_budget"
+	os.MkdirAll(filepath.Join(tempDir, ".git", "refs", "heads"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, ".gitsentry"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	budget := NewBudget(2)
+	if _, err := NewRefMonitor(filepath.Join(tempDir, ".git"), NewBudget(0), func() {}); err == nil {
+		t.Error("Ref monitor should fail when the budget is exhausted")
+	}
+	
+	cm, err := NewConfigMonitor([]string{filepath.Join(tempDir, ".gitsentry.yaml"), filepath.Join(tempDir, ".gitsentry", "config.yaml")}, budget, func() {})
+	if err != nil {
+		t.Fatalf("Failed to create config monitor: %v", err)
+	}
+	if used, _ := budget.Usage(); used != 2 {
+		t.Errorf("Config monitor should use one watch per directory, got %d", used)
+	}
+	
+	cm.Stop()
+	if used, _ := budget.Usage(); used != 0 {
+		t.Errorf("Stopping the config monitor should release its watches, got %d", used)
+	}
+}
+
+func TestResetTimerDrainsStaleTick(t *testing.T) {
+	timer := time.NewTimer(time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	
+	resetTimer(timer, time.Hour)
+	select {
+	case <-timer.C:
+		t.Error("A tick from before the reset should not be delivered")
+	case <-time.After(50 * time.Millisecond):
+	}
+	
+	resetTimer(timer, time.Millisecond)
+	select {
+	case <-timer.C:
+	case <-time.After(time.Second):
+		t.Error("Reset timer should fire after the new duration")
+	}
+}
@@ -0,0 +1,112 @@
+package monitor
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type RefMonitor struct {
+	watcher  *fsnotify.Watcher
+	watches  *watchSet
+	gitDir   string
+	callback func()
+	done     chan bool
+}
+
+func NewRefMonitor(gitDir string, budget *Budget, callback func()) (*RefMonitor, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	
+	rm := &RefMonitor{
+		watcher:  watcher,
+		watches:  newWatchSet(watcher, budget),
+		gitDir:   gitDir,
+		callback: callback,
+		done:     make(chan bool),
+	}
+	
+	if err := rm.watches.add(gitDir); err != nil {
+		rm.watches.close()
+		return nil, err
+	}
+	
+	rm.watches.add(filepath.Join(gitDir, "refs"))
+	rm.addRefDirs(filepath.Join(gitDir, "refs", "heads"))
+	rm.addRefDirs(filepath.Join(gitDir, "refs", "remotes"))
+	
+	go rm.watch()
+	
+	return rm, nil
+}
+
+func (rm *RefMonitor) addRefDirs(root string) {
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		
+		if d.IsDir() {
+			rm.watches.add(path)
+		}
+		
+		return nil
+	})
+}
+
+func (rm *RefMonitor) isRefEvent(name string) bool {
+	if strings.HasSuffix(name, ".lock") {
+		return false
+	}
+	
+	rel, err := filepath.Rel(rm.gitDir, name)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	
+	return rel == "HEAD" || rel == "packed-refs" || strings.HasPrefix(rel, "refs/")
+}
+
+func (rm *RefMonitor) watch() {
+	for {
+		select {
+		case event, ok := <-rm.watcher.Events:
+			if !ok {
+				return
+			}
+			
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					rm.addRefDirs(event.Name)
+					continue
+				}
+			}
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				rm.watches.remove(event.Name)
+			}
+			
+			if rm.isRefEvent(event.Name) {
+				rm.callback()
+			}
+			
+		case _, ok := <-rm.watcher.Errors:
+			if !ok {
+				return
+			}
+			
+		case <-rm.done:
+			return
+		}
+	}
+}
+
+func (rm *RefMonitor) Stop() {
+	close(rm.done)
+	rm.watches.close()
+}
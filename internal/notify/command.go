@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const commandTimeout = 10 * time.Second
+
+type CommandNotifier struct {
+	command []string
+	timeout time.Duration
+}
+
+func NewCommandNotifier(command []string) (*CommandNotifier, error) {
+	if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+		return nil, fmt.Errorf("command notifier requires a command")
+	}
+	
+	return &CommandNotifier{
+		command: command,
+		timeout: commandTimeout,
+	}, nil
+}
+
+func (c *CommandNotifier) Name() string {
+	return TypeCommand
+}
+
+func (c *CommandNotifier) Notify(n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	
+	cmd := exec.CommandContext(ctx, c.command[0], c.command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", c.command[0], err, strings.TrimSpace(string(output)))
+	}
+	
+	return nil
+}
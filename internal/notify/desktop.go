@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gitsentry/internal/rules"
+)
+
+const desktopTimeout = 5 * time.Second
+
+type DesktopNotifier struct {
+	binary string
+}
+
+func NewDesktopNotifier() *DesktopNotifier {
+	return &DesktopNotifier{binary: "notify-send"}
+}
+
+func (d *DesktopNotifier) Name() string {
+	return TypeDesktop
+}
+
+func (d *DesktopNotifier) Notify(n Notification) error {
+	path, err := exec.LookPath(d.binary)
+	if err != nil {
+		return fmt.Errorf("%s not available: %w", d.binary, err)
+	}
+	
+	urgency := "low"
+	switch n.Severity() {
+	case rules.SeverityWarning:
+		urgency = "normal"
+	case rules.SeverityHigh:
+		urgency = "critical"
+	}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), desktopTimeout)
+	defer cancel()
+	
+	cmd := exec.CommandContext(ctx, path,
+		"--app-name=GitSentry",
+		"--urgency="+urgency,
+		n.Title,
+		strings.Join(n.Lines, "\n"),
+	)
+	
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	
+	return nil
+}
@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/rules"
+)
+
+const (
+	TypeTerminal = "terminal"
+	TypeDesktop  = "desktop"
+	TypeCommand  = "command"
+	TypeWebhook  = "webhook"
+)
+
+var stdout = NewTerminalNotifier(os.Stdout)
+
+type Notification struct {
+	RepoPath    string             `json:"repo_path"`
+	Action      rules.Action       `json:"action"`
+	Title       string             `json:"title"`
+	Lines       []string           `json:"lines"`
+	Suggestions []rules.Suggestion `json:"suggestions"`
+	Time        time.Time          `json:"time"`
+}
+
+func (n Notification) Severity() rules.Severity {
+	severity := rules.SeverityInfo
+	for _, suggestion := range n.Suggestions {
+		switch suggestion.Severity {
+		case rules.SeverityHigh:
+			return rules.SeverityHigh
+		case rules.SeverityWarning:
+			severity = rules.SeverityWarning
+		}
+	}
+	
+	return severity
+}
+
+type Notifier interface {
+	Name() string
+	Notify(n Notification) error
+}
+
+type Multi []Notifier
+
+func (m Multi) Name() string {
+	return "multi"
+}
+
+func (m Multi) Notify(n Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(n); err != nil {
+			errs = append(errs, fmt.Errorf("%s notifier: %w", notifier.Name(), err))
+		}
+	}
+	
+	return errors.Join(errs...)
+}
+
+func (m Multi) Flush() error {
+	var errs []error
+	for _, notifier := range m {
+		if flusher, ok := notifier.(Flusher); ok {
+			if err := flusher.Flush(); err != nil {
+				errs = append(errs, fmt.Errorf("%s notifier: %w", notifier.Name(), err))
+			}
+		}
+	}
+	
+	return errors.Join(errs...)
+}
+
+func Build(settings []config.NotifierConfig, gitsentryDir string) (Notifier, error) {
+	if gitsentryDir != "" {
+		if err := PruneOutbox(gitsentryDir, settings); err != nil {
+			return nil, fmt.Errorf("failed to prune webhook outbox: %w", err)
+		}
+	}
+	
+	if len(settings) == 0 {
+		return Multi{stdout}, nil
+	}
+	
+	var notifiers Multi
+	for _, setting := range settings {
+		switch setting.Type {
+		case TypeTerminal:
+			notifiers = append(notifiers, stdout)
+		case TypeDesktop:
+			notifiers = append(notifiers, NewDesktopNotifier())
+		case TypeCommand:
+			notifier, err := NewCommandNotifier(setting.Command)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, notifier)
+		case TypeWebhook:
+			notifier, err := NewWebhookNotifier(setting.URL, setting.Format, gitsentryDir)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, notifier)
+		default:
+			return nil, fmt.Errorf("unknown notifier type: %q", setting.Type)
+		}
+	}
+	
+	return notifiers, nil
+}
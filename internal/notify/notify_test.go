@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/rules"
+)
+
+func testNotification() Notification {
+	return Notification{
+		RepoPath: "/work/project",
+		Action:   rules.ActionPush,
+		Title:    "GitSentry suggests pushing your commits for backup!",
+		Lines:    []string{"Unpushed commits: 4", "Run 'git push' when ready"},
+		Suggestions: []rules.Suggestion{
+			{ID: rules.UnpushedCommitsRule, Action: rules.ActionPush, Severity: rules.SeverityInfo},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	notifier, err := Build(nil, "")
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if multi, ok := notifier.(Multi); !ok || len(multi) != 1 || multi[0].Name() != TypeTerminal {
+		t.Errorf("Expected terminal notifier by default, got %v", notifier)
+	}
+	
+	notifier, err = Build([]config.NotifierConfig{
+		{Type: TypeTerminal},
+		{Type: TypeDesktop},
+		{Type: TypeCommand, Command: []string{"cat"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if multi := notifier.(Multi); len(multi) != 3 {
+		t.Errorf("Expected 3 stacked notifiers, got %d", len(multi))
+	}
+	
+	if _, err := Build([]config.NotifierConfig{{Type: "pager"}}, ""); err == nil {
+		t.Error("Should reject unknown notifier type")
+	}
+	
+	if _, err := Build([]config.NotifierConfig{{Type: TypeCommand}}, ""); err == nil {
+		t.Error("Should reject command notifier without a command")
+	}
+}
+
+func TestTerminalNotifier(t *testing.T) {
+	var out bytes.Buffer
+	notifier := NewTerminalNotifier(&out)
+	
+	if err := notifier.Notify(testNotification()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	
+	expected := "\nGitSentry suggests pushing your commits for backup!\n   Unpushed commits: 4\n   Run 'git push' when ready\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected terminal output:\n%q", out.String())
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	tempDir := "test_command_notifier"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	outPath := filepath.Join(tempDir, "payload.json")
+	notifier, err := NewCommandNotifier([]string{"sh", "-c", "cat > " + outPath})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	
+	if err := notifier.Notify(testNotification()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("Command did not receive payload: %v", err)
+	}
+	
+	var received Notification
+	if err := json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("Payload is not valid JSON: %v", err)
+	}
+	
+	if received.Action != rules.ActionPush || len(received.Suggestions) != 1 {
+		t.Errorf("Unexpected payload: %+v", received)
+	}
+	
+	failing, _ := NewCommandNotifier([]string{"sh", "-c", "echo boom >&2; exit 3"})
+	err = failing.Notify(testNotification())
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected command failure with output, got %v", err)
+	}
+}
+
+type failingNotifier struct {
+	calls int
+}
+
+func (f *failingNotifier) Name() string {
+	return "failing"
+}
+
+func (f *failingNotifier) Notify(n Notification) error {
+	f.calls++
+	return errors.New("unavailable")
+}
+
+func TestMultiDeliversToEveryBackend(t *testing.T) {
+	var out bytes.Buffer
+	first := &failingNotifier{}
+	multi := Multi{first, NewTerminalNotifier(&out)}
+	
+	err := multi.Notify(testNotification())
+	if err == nil || !strings.Contains(err.Error(), "failing notifier") {
+		t.Errorf("Expected backend error to be reported, got %v", err)
+	}
+	
+	if first.calls != 1 || out.Len() == 0 {
+		t.Error("A failing backend should not prevent delivery to the others")
+	}
+}
@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitsentry/internal/security"
+)
+
+const OutboxDir = "outbox"
+
+var (
+	outboxesMu sync.Mutex
+	outboxes   = make(map[string]*Outbox)
+)
+
+type delivery struct {
+	ID          string          `json:"id"`
+	URL         string          `json:"url"`
+	Body        json.RawMessage `json:"body"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+	LastError   string          `json:"last_error,omitempty"`
+}
+
+type Outbox struct {
+	mu  sync.Mutex
+	dir string
+	seq int
+}
+
+func openOutbox(dir string) *Outbox {
+	outboxesMu.Lock()
+	defer outboxesMu.Unlock()
+	
+	if outbox, ok := outboxes[dir]; ok {
+		return outbox
+	}
+	
+	outbox := &Outbox{dir: dir}
+	outboxes[dir] = outbox
+	return outbox
+}
+
+func (o *Outbox) enqueue(d delivery) error {
+	o.seq++
+	d.ID = fmt.Sprintf("%020d-%04d", time.Now().UnixNano(), o.seq%10000)
+	
+	return o.save(d)
+}
+
+func (o *Outbox) save(d delivery) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	
+	return security.SecureWriteFile(filepath.Join(o.dir, d.ID+".json"), data)
+}
+
+func (o *Outbox) remove(d delivery) error {
+	err := security.SecureRemoveFile(filepath.Join(o.dir, d.ID+".json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	
+	return err
+}
+
+func (o *Outbox) pending() ([]delivery, error) {
+	entries, err := os.ReadDir(o.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	
+	var deliveries []delivery
+	for _, name := range names {
+		data, err := security.SecureReadFile(filepath.Join(o.dir, name))
+		if err != nil {
+			continue
+		}
+		
+		var d delivery
+		if err := json.Unmarshal(data, &d); err != nil || d.ID == "" {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	
+	return deliveries, nil
+}
+
+func (o *Outbox) prune(keep func(delivery) bool) error {
+	deliveries, err := o.pending()
+	if err != nil {
+		return err
+	}
+	
+	for _, d := range deliveries {
+		if keep(d) {
+			continue
+		}
+		if err := o.remove(d); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+type TerminalNotifier struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewTerminalNotifier(out io.Writer) *TerminalNotifier {
+	return &TerminalNotifier{out: out}
+}
+
+func (t *TerminalNotifier) Name() string {
+	return TypeTerminal
+}
+
+func (t *TerminalNotifier) Notify(n Notification) error {
+	var sb strings.Builder
+	sb.WriteString("\n" + n.Title + "\n")
+	for _, line := range n.Lines {
+		sb.WriteString("   " + line + "\n")
+	}
+	
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	
+	_, err := io.WriteString(t.out, sb.String())
+	return err
+}
@@ -0,0 +1,283 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/rules"
+	"gitsentry/internal/security"
+)
+
+const (
+	FormatGeneric = "generic"
+	FormatSlack   = "slack"
+	
+	PayloadVersion = 1
+	SecretFile     = "webhook.secret"
+	
+	SignatureHeader = "X-GitSentry-Signature"
+	TimestampHeader = "X-GitSentry-Timestamp"
+	VersionHeader   = "X-GitSentry-Version"
+	
+	webhookTimeout     = 10 * time.Second
+	webhookBaseBackoff = 10 * time.Second
+	webhookMaxBackoff  = 30 * time.Minute
+	webhookMaxAttempts = 10
+)
+
+type Flusher interface {
+	Flush() error
+}
+
+type WebhookPayload struct {
+	Version     int                `json:"version"`
+	Event       string             `json:"event"`
+	RepoPath    string             `json:"repo_path"`
+	Action      rules.Action       `json:"action"`
+	Severity    rules.Severity     `json:"severity"`
+	Title       string             `json:"title"`
+	Lines       []string           `json:"lines"`
+	Suggestions []rules.Suggestion `json:"suggestions"`
+	Time        time.Time          `json:"time"`
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+type WebhookNotifier struct {
+	url    string
+	format string
+	secret []byte
+	outbox *Outbox
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookNotifier(rawURL, format, gitsentryDir string) (*WebhookNotifier, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("webhook notifier requires an http(s) url, got %q", rawURL)
+	}
+	
+	if format == "" {
+		format = FormatGeneric
+	}
+	if format != FormatGeneric && format != FormatSlack {
+		return nil, fmt.Errorf("unknown webhook format: %q", format)
+	}
+	
+	secret, err := LoadOrCreateSecret(gitsentryDir)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &WebhookNotifier{
+		url:    rawURL,
+		format: format,
+		secret: secret,
+		outbox: openOutbox(filepath.Join(gitsentryDir, OutboxDir)),
+		client: &http.Client{Timeout: webhookTimeout},
+		now:    time.Now,
+	}, nil
+}
+
+func PruneOutbox(gitsentryDir string, settings []config.NotifierConfig) error {
+	configured := make(map[string]bool)
+	for _, setting := range settings {
+		if setting.Type == TypeWebhook {
+			configured[setting.URL] = true
+		}
+	}
+	
+	outbox := openOutbox(filepath.Join(gitsentryDir, OutboxDir))
+	outbox.mu.Lock()
+	defer outbox.mu.Unlock()
+	
+	return outbox.prune(func(d delivery) bool {
+		return configured[d.URL]
+	})
+}
+
+func LoadOrCreateSecret(gitsentryDir string) ([]byte, error) {
+	path := filepath.Join(gitsentryDir, SecretFile)
+	
+	data, err := security.SecureReadFile(path)
+	if err == nil {
+		if secret := strings.TrimSpace(string(data)); secret != "" {
+			return []byte(secret), nil
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read webhook secret: %w", err)
+	}
+	
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	
+	secret := hex.EncodeToString(raw)
+	if err := security.SecurePrivateWriteFile(path, []byte(secret+"\n")); err != nil {
+		return nil, fmt.Errorf("failed to save webhook secret: %w", err)
+	}
+	
+	return []byte(secret), nil
+}
+
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifySignature(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+func (w *WebhookNotifier) Name() string {
+	return TypeWebhook
+}
+
+func (w *WebhookNotifier) Notify(n Notification) error {
+	body, err := w.encode(n)
+	if err != nil {
+		return err
+	}
+	
+	w.outbox.mu.Lock()
+	err = w.outbox.enqueue(delivery{URL: w.url, Body: body, NextAttempt: w.now()})
+	w.outbox.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to queue webhook: %w", err)
+	}
+	
+	return nil
+}
+
+func (w *WebhookNotifier) Flush() error {
+	w.outbox.mu.Lock()
+	defer w.outbox.mu.Unlock()
+	
+	deliveries, err := w.outbox.pending()
+	if err != nil {
+		return fmt.Errorf("failed to read webhook outbox: %w", err)
+	}
+	
+	var errs []error
+	for _, d := range deliveries {
+		if d.URL != w.url || w.now().Before(d.NextAttempt) {
+			continue
+		}
+		
+		sendErr := w.send(d.Body)
+		if sendErr == nil {
+			if err := w.outbox.remove(d); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		
+		d.Attempts++
+		d.LastError = sendErr.Error()
+		
+		if d.Attempts >= webhookMaxAttempts {
+			errs = append(errs, fmt.Errorf("dropping webhook after %d attempts: %w", d.Attempts, sendErr))
+			if err := w.outbox.remove(d); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		
+		d.NextAttempt = w.now().Add(backoff(d.Attempts))
+		if err := w.outbox.save(d); err != nil {
+			errs = append(errs, err)
+		}
+		errs = append(errs, fmt.Errorf("delivery failed, will retry at %s: %w", d.NextAttempt.Format(time.RFC3339), sendErr))
+	}
+	
+	return errors.Join(errs...)
+}
+
+func (w *WebhookNotifier) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	
+	timestamp := strconv.FormatInt(w.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GitSentry")
+	req.Header.Set(VersionHeader, strconv.Itoa(PayloadVersion))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(w.secret, timestamp, body))
+	
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	
+	return nil
+}
+
+func (w *WebhookNotifier) encode(n Notification) ([]byte, error) {
+	if w.format == FormatSlack {
+		var sb strings.Builder
+		sb.WriteString("*" + n.Title + "*")
+		if n.RepoPath != "" {
+			sb.WriteString(" (`" + n.RepoPath + "`)")
+		}
+		for _, line := range n.Lines {
+			sb.WriteString("\n• " + line)
+		}
+		
+		return json.Marshal(slackPayload{Text: sb.String()})
+	}
+	
+	return json.Marshal(WebhookPayload{
+		Version:     PayloadVersion,
+		Event:       "suggestion",
+		RepoPath:    n.RepoPath,
+		Action:      n.Action,
+		Severity:    n.Severity(),
+		Title:       n.Title,
+		Lines:       n.Lines,
+		Suggestions: n.Suggestions,
+		Time:        n.Time,
+	})
+}
+
+func backoff(attempts int) time.Duration {
+	delay := webhookBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	
+	return delay
+}
@@ -0,0 +1,259 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/rules"
+)
+
+type webhookRecorder struct {
+	mu       sync.Mutex
+	failures int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *webhookRecorder) requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	return len(r.bodies)
+}
+
+func pendingCount(t *testing.T, dir string) int {
+	entries, err := os.ReadDir(filepath.Join(dir, OutboxDir))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("Failed to read outbox: %v", err)
+	}
+	
+	return len(entries)
+}
+
+func TestWebhookSignedDelivery(t *testing.T) {
+	tempDir := "test_webhook"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+	
+	notifier, err := NewWebhookNotifier(server.URL, "", tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create webhook notifier: %v", err)
+	}
+	
+	if err := notifier.Notify(testNotification()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	
+	if recorder.requests() != 0 || pendingCount(t, tempDir) != 1 {
+		t.Fatalf("Notify should only queue the delivery, got %d requests", recorder.requests())
+	}
+	
+	if err := notifier.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	
+	if recorder.requests() != 1 {
+		t.Fatalf("Expected 1 request, got %d", recorder.requests())
+	}
+	
+	secret, err := LoadOrCreateSecret(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to load secret: %v", err)
+	}
+	
+	headers, body := recorder.headers[0], recorder.bodies[0]
+	if !VerifySignature(secret, headers.Get(TimestampHeader), body, headers.Get(SignatureHeader)) {
+		t.Error("Payload signature does not verify with the repository secret")
+	}
+	
+	if VerifySignature([]byte("other"), headers.Get(TimestampHeader), body, headers.Get(SignatureHeader)) {
+		t.Error("Signature should not verify with a different secret")
+	}
+	
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	
+	if payload.Version != PayloadVersion || payload.Action != rules.ActionPush || len(payload.Suggestions) != 1 {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+	
+	info, err := os.Stat(filepath.Join(tempDir, SecretFile))
+	if err != nil {
+		t.Fatalf("Secret file missing: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Secret file should be private, got %v", info.Mode().Perm())
+	}
+	
+	if pendingCount(t, tempDir) != 0 {
+		t.Error("Outbox should be empty after successful delivery")
+	}
+}
+
+func TestWebhookRetriesFromOutbox(t *testing.T) {
+	tempDir := "test_webhook_retry"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	recorder := &webhookRecorder{failures: 1}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+	
+	notifier, err := NewWebhookNotifier(server.URL, FormatGeneric, tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create webhook notifier: %v", err)
+	}
+	
+	now := time.Now()
+	notifier.now = func() time.Time { return now }
+	
+	if err := notifier.Notify(testNotification()); err != nil {
+		t.Fatalf("Queueing a delivery should not fail: %v", err)
+	}
+	
+	if err := notifier.Flush(); err == nil {
+		t.Error("Expected failed delivery to be reported")
+	}
+	
+	if pendingCount(t, tempDir) != 1 {
+		t.Fatal("Failed delivery should stay in the outbox")
+	}
+	
+	if err := notifier.Flush(); err != nil {
+		t.Errorf("Flush before backoff should be a no-op, got %v", err)
+	}
+	if recorder.requests() != 1 {
+		t.Errorf("Delivery should not be retried before its backoff, got %d requests", recorder.requests())
+	}
+	
+	now = now.Add(backoff(1) + time.Second)
+	if err := notifier.Flush(); err != nil {
+		t.Fatalf("Retry failed: %v", err)
+	}
+	
+	if recorder.requests() != 2 || pendingCount(t, tempDir) != 0 {
+		t.Errorf("Expected retry to succeed and clear the outbox, got %d requests", recorder.requests())
+	}
+}
+
+func TestOutboxPrunedWhenWebhookRemoved(t *testing.T) {
+	tempDir := "test_webhook_prune"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	recorder := &webhookRecorder{failures: 10}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+	
+	notifier, err := NewWebhookNotifier(server.URL, FormatGeneric, tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create webhook notifier: %v", err)
+	}
+	notifier.Notify(testNotification())
+	
+	if _, err := Build([]config.NotifierConfig{{Type: TypeWebhook, URL: server.URL}}, tempDir); err != nil {
+		t.Fatalf("Failed to build notifiers: %v", err)
+	}
+	if pendingCount(t, tempDir) != 1 {
+		t.Fatal("Deliveries for a configured webhook should be kept")
+	}
+	
+	if _, err := Build([]config.NotifierConfig{{Type: TypeWebhook, URL: server.URL + "/moved"}}, tempDir); err != nil {
+		t.Fatalf("Failed to build notifiers: %v", err)
+	}
+	if pendingCount(t, tempDir) != 0 {
+		t.Error("Deliveries for a webhook that is no longer configured should be dropped")
+	}
+}
+
+func TestWebhookSlackFormat(t *testing.T) {
+	tempDir := "test_webhook_slack"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+	
+	notifier, err := NewWebhookNotifier(server.URL, FormatSlack, tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create webhook notifier: %v", err)
+	}
+	
+	if err := notifier.Notify(testNotification()); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if err := notifier.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	
+	var payload map[string]interface{}
+	if err := json.Unmarshal(recorder.bodies[0], &payload); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	
+	text, _ := payload["text"].(string)
+	if !strings.Contains(text, "pushing your commits") || !strings.Contains(text, "Unpushed commits: 4") {
+		t.Errorf("Unexpected Slack text: %q", text)
+	}
+}
+
+func TestWebhookRejectsInvalidSettings(t *testing.T) {
+	tempDir := "test_webhook_invalid"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	if _, err := NewWebhookNotifier("ftp://relay.local/hook", "", tempDir); err == nil {
+		t.Error("Should reject non-http webhook url")
+	}
+	
+	if _, err := NewWebhookNotifier("https://relay.local/hook", "xml", tempDir); err == nil {
+		t.Error("Should reject unknown webhook format")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	if backoff(1) != webhookBaseBackoff || backoff(2) != 2*webhookBaseBackoff {
+		t.Errorf("Unexpected backoff progression: %v, %v", backoff(1), backoff(2))
+	}
+	
+	if backoff(50) != webhookMaxBackoff {
+		t.Errorf("Backoff should be capped, got %v", backoff(50))
+	}
+}
@@ -0,0 +1,149 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gitsentry/internal/config"
+	"gitsentry/internal/security"
+)
+
+const fileName = "repos.yaml"
+
+type Entry struct {
+	Path  string    `yaml:"path"`
+	Added time.Time `yaml:"added"`
+}
+
+type Registry struct {
+	Repos []Entry `yaml:"repos"`
+	path  string
+}
+
+func DefaultPath() (string, error) {
+	dir, err := config.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	
+	return filepath.Join(dir, fileName), nil
+}
+
+func Load() (*Registry, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	
+	return LoadFrom(path)
+}
+
+func LoadFrom(path string) (*Registry, error) {
+	if err := security.AllowRoot(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	
+	reg := &Registry{path: path}
+	
+	data, err := security.SecureReadFile(path)
+	if os.IsNotExist(err) {
+		return reg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry: %w", err)
+	}
+	
+	if err := yaml.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("failed to parse registry %s: %w", path, err)
+	}
+	
+	return reg, nil
+}
+
+func (r *Registry) Save() error {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	
+	return security.SecureWriteFile(r.path, data)
+}
+
+func (r *Registry) Path() string {
+	return r.path
+}
+
+func (r *Registry) Add(repoPath string) (string, error) {
+	absPath, err := normalize(repoPath)
+	if err != nil {
+		return "", err
+	}
+	
+	info, err := os.Stat(absPath)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("not a directory: %s", absPath)
+	}
+	
+	if r.Contains(absPath) {
+		return absPath, fmt.Errorf("repository already registered: %s", absPath)
+	}
+	
+	r.Repos = append(r.Repos, Entry{Path: absPath, Added: time.Now()})
+	sort.Slice(r.Repos, func(i, j int) bool {
+		return r.Repos[i].Path < r.Repos[j].Path
+	})
+	
+	return absPath, nil
+}
+
+func (r *Registry) Remove(repoPath string) (string, bool) {
+	absPath, err := normalize(repoPath)
+	if err != nil {
+		return repoPath, false
+	}
+	
+	for i, entry := range r.Repos {
+		if entry.Path == absPath {
+			r.Repos = append(r.Repos[:i], r.Repos[i+1:]...)
+			return absPath, true
+		}
+	}
+	
+	return absPath, false
+}
+
+func (r *Registry) Contains(absPath string) bool {
+	for _, entry := range r.Repos {
+		if entry.Path == absPath {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func (r *Registry) Paths() []string {
+	paths := make([]string, len(r.Repos))
+	for i, entry := range r.Repos {
+		paths[i] = entry.Path
+	}
+	
+	return paths
+}
+
+func normalize(repoPath string) (string, error) {
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", repoPath, err)
+	}
+	
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = resolved
+	}
+	
+	return filepath.Clean(absPath), nil
+}
@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryAddRemove(t *testing.T) {
+	tempDir := "test_registry"
+	repoDir := filepath.Join(tempDir, "project")
+	os.MkdirAll(repoDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	regPath, _ := filepath.Abs(filepath.Join(tempDir, "config", fileName))
+	
+	reg, err := LoadFrom(regPath)
+	if err != nil {
+		t.Fatalf("Failed to load empty registry: %v", err)
+	}
+	
+	added, err := reg.Add(repoDir)
+	if err != nil {
+		t.Fatalf("Failed to add repository: %v", err)
+	}
+	if !filepath.IsAbs(added) {
+		t.Errorf("Registered path should be absolute, got %s", added)
+	}
+	
+	if _, err := reg.Add(repoDir); err == nil {
+		t.Error("Adding the same repository twice should fail")
+	}
+	
+	if _, err := reg.Add(filepath.Join(tempDir, "missing")); err == nil {
+		t.Error("Adding a missing directory should fail")
+	}
+	
+	if err := reg.Save(); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+	
+	loaded, err := LoadFrom(regPath)
+	if err != nil {
+		t.Fatalf("Failed to reload registry: %v", err)
+	}
+	if len(loaded.Paths()) != 1 || loaded.Paths()[0] != added {
+		t.Errorf("Unexpected registry contents: %v", loaded.Paths())
+	}
+	
+	if _, ok := loaded.Remove(repoDir); !ok {
+		t.Error("Remove should report the repository as removed")
+	}
+	if _, ok := loaded.Remove(repoDir); ok {
+		t.Error("Removing twice should report not found")
+	}
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"fmt"
+
+	"gitsentry/internal/config"
+)
+
+const (
+	FilesChangedRule    = "files_changed"
+	LinesChangedRule    = "lines_changed"
+	TimeSinceCommitRule = "time_since_commit"
+	UnpushedCommitsRule = "unpushed_commits"
+)
+
+func init() {
+	Register(FilesChangedRule, func(cfg *config.Config, params Params) (Rule, error) {
+		return newThresholdRule(FilesChangedRule, ActionCommit, "files_changed", params, cfg.Rules.MaxFilesChanged,
+			func(s Snapshot) (int, bool) { return s.FilesChanged, true },
+			"%d files changed (threshold %d)")
+	})
+	
+	Register(LinesChangedRule, func(cfg *config.Config, params Params) (Rule, error) {
+		return newThresholdRule(LinesChangedRule, ActionCommit, "lines_changed", params, cfg.Rules.MaxLinesChanged,
+			func(s Snapshot) (int, bool) { return s.LinesChanged(), true },
+			"%d lines changed (threshold %d)")
+	})
+	
+	Register(TimeSinceCommitRule, func(cfg *config.Config, params Params) (Rule, error) {
+		return newThresholdRule(TimeSinceCommitRule, ActionCommit, "minutes_since_commit", params, cfg.Rules.MaxMinutesSinceCommit,
+			func(s Snapshot) (int, bool) {
+				elapsed, ok := s.SinceLastCommit()
+				return int(elapsed.Minutes()), ok
+			},
+			"%d minutes since last commit (threshold %d)")
+	})
+	
+	Register(UnpushedCommitsRule, func(cfg *config.Config, params Params) (Rule, error) {
+		return newThresholdRule(UnpushedCommitsRule, ActionPush, "unpushed_commits", params, cfg.Rules.MaxUnpushedCommits,
+			func(s Snapshot) (int, bool) { return s.UnpushedCommits, true },
+			"%d unpushed commits (threshold %d)")
+	})
+}
+
+type thresholdRule struct {
+	id        string
+	action    Action
+	metric    string
+	threshold int
+	value     func(Snapshot) (int, bool)
+	reason    string
+}
+
+func newThresholdRule(id string, action Action, metric string, params Params, fallback int, value func(Snapshot) (int, bool), reason string) (Rule, error) {
+	if err := params.Check("threshold"); err != nil {
+		return nil, err
+	}
+	
+	threshold := params.Int("threshold", fallback)
+	if threshold < 1 {
+		return nil, fmt.Errorf("threshold must be at least 1, got %d", threshold)
+	}
+	
+	return &thresholdRule{
+		id:        id,
+		action:    action,
+		metric:    metric,
+		threshold: threshold,
+		value:     value,
+		reason:    reason,
+	}, nil
+}
+
+func (r *thresholdRule) ID() string {
+	return r.id
+}
+
+func (r *thresholdRule) Action() Action {
+	return r.action
+}
+
+func (r *thresholdRule) Evaluate(snapshot Snapshot) *Suggestion {
+	value, ok := r.value(snapshot)
+	if !ok || value < r.threshold {
+		return nil
+	}
+	
+	severity := SeverityInfo
+	if value >= 2*r.threshold {
+		severity = SeverityWarning
+	}
+	
+	return &Suggestion{
+		ID:       r.id,
+		Action:   r.action,
+		Severity: severity,
+		Value:    value,
+		Reasons:  []string{fmt.Sprintf(r.reason, value, r.threshold)},
+		Metrics: map[string]int{
+			r.metric:    value,
+			"threshold": r.threshold,
+		},
+	}
+}
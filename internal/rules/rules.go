@@ -0,0 +1,219 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/secrets"
+)
+
+type Action string
+
+const (
+	ActionCommit Action = "commit"
+	ActionPush   Action = "push"
+	ActionReview Action = "review"
+)
+
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityHigh    Severity = "high"
+)
+
+type Snapshot struct {
+	Branch          string
+	ChangedFiles    []string
+	FilesChanged    int
+	LinesAdded      int
+	LinesRemoved    int
+	LastCommit      time.Time
+	UnpushedCommits int
+	Files           map[string]FileChange
+	Secrets         []secrets.Finding
+	InBurst         bool
+	Idle            time.Duration
+	Now             time.Time
+}
+
+func (s Snapshot) LinesChanged() int {
+	return s.LinesAdded + s.LinesRemoved
+}
+
+func (s Snapshot) SinceLastCommit() (time.Duration, bool) {
+	if s.LastCommit.IsZero() {
+		return 0, false
+	}
+	
+	now := s.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	
+	return now.Sub(s.LastCommit), true
+}
+
+type Suggestion struct {
+	ID       string         `json:"id"`
+	Action   Action         `json:"action"`
+	Severity Severity       `json:"severity"`
+	Value    int            `json:"value"`
+	Reasons  []string       `json:"reasons"`
+	Metrics  map[string]int `json:"metrics"`
+	Scope    string         `json:"scope,omitempty"`
+}
+
+func (s Suggestion) Key() string {
+	if s.Scope == "" {
+		return s.ID
+	}
+	
+	return s.ID + "@" + s.Scope
+}
+
+type Rule interface {
+	ID() string
+	Action() Action
+	Evaluate(snapshot Snapshot) *Suggestion
+}
+
+type Params map[string]int
+
+type Factory func(cfg *config.Config, params Params) (Rule, error)
+
+var (
+	registryMu sync.RWMutex
+	factories  = make(map[string]Factory)
+)
+
+func Register(id string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	
+	if _, exists := factories[id]; exists {
+		panic(fmt.Sprintf("rule already registered: %s", id))
+	}
+	
+	factories[id] = factory
+}
+
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	
+	ids := make([]string, 0, len(factories))
+	for id := range factories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	
+	return ids
+}
+
+func IsEnabled(cfg *config.Config, id string) bool {
+	setting, ok := cfg.RuleSettings[id]
+	if !ok || setting.Enabled == nil {
+		return true
+	}
+	
+	return *setting.Enabled
+}
+
+func IsUrgent(cfg *config.Config, id string) bool {
+	setting, ok := cfg.RuleSettings[id]
+	return ok && setting.Urgent != nil && *setting.Urgent
+}
+
+func Cooldown(cfg *config.Config, id string) time.Duration {
+	minutes := cfg.Suggestions.CooldownMinutes
+	if setting, ok := cfg.RuleSettings[id]; ok && setting.CooldownMinutes != nil {
+		minutes = *setting.CooldownMinutes
+	}
+	
+	return time.Duration(minutes) * time.Minute
+}
+
+func Build(cfg *config.Config) ([]Rule, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	
+	for id := range cfg.RuleSettings {
+		if _, ok := factories[id]; !ok {
+			return nil, fmt.Errorf("unknown rule: %s", id)
+		}
+	}
+	
+	ids := make([]string, 0, len(factories))
+	for id := range factories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	
+	var built []Rule
+	for _, id := range ids {
+		if !IsEnabled(cfg, id) {
+			continue
+		}
+		
+		if cooldown := cfg.RuleSettings[id].CooldownMinutes; cooldown != nil && *cooldown < 0 {
+			return nil, fmt.Errorf("invalid settings for rule %s: cooldown_minutes must not be negative", id)
+		}
+		
+		rule, err := factories[id](cfg, Params(cfg.RuleSettings[id].Params))
+		if err != nil {
+			return nil, fmt.Errorf("invalid settings for rule %s: %w", id, err)
+		}
+		
+		if rule.Action() == ActionCommit && !cfg.AutoSuggestCommits {
+			continue
+		}
+		if rule.Action() == ActionPush && !cfg.AutoSuggestPushes {
+			continue
+		}
+		
+		built = append(built, rule)
+	}
+	
+	return built, nil
+}
+
+func Evaluate(rules []Rule, snapshot Snapshot) []Suggestion {
+	var suggestions []Suggestion
+	for _, rule := range rules {
+		if suggestion := rule.Evaluate(snapshot); suggestion != nil {
+			suggestions = append(suggestions, *suggestion)
+		}
+	}
+	
+	return suggestions
+}
+
+func (p Params) Int(name string, fallback int) int {
+	if value, ok := p[name]; ok {
+		return value
+	}
+	
+	return fallback
+}
+
+func (p Params) Check(allowed ...string) error {
+	for name := range p {
+		found := false
+		for _, a := range allowed {
+			if name == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown parameter: %s", name)
+		}
+	}
+	
+	return nil
+}
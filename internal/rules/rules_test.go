@@ -0,0 +1,253 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/secrets"
+)
+
+func TestBuildDefaultRules(t *testing.T) {
+	built, err := Build(config.DefaultConfig())
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	
+	if len(built) != 5 {
+		t.Errorf("Expected 5 default rules, got %d", len(built))
+	}
+}
+
+func TestBuildHonoursSettings(t *testing.T) {
+	cfg := config.DefaultConfig()
+	disabled := false
+	cfg.RuleSettings = map[string]config.RuleSetting{
+		LinesChangedRule: {Enabled: &disabled},
+		FilesChangedRule: {Params: map[string]int{"threshold": 2}},
+	}
+	cfg.AutoSuggestPushes = false
+	
+	built, err := Build(cfg)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	
+	for _, rule := range built {
+		if rule.ID() == LinesChangedRule {
+			t.Error("Disabled rule should not be built")
+		}
+		if rule.Action() == ActionPush {
+			t.Error("Push rules should be skipped when push suggestions are off")
+		}
+	}
+	
+	suggestions := Evaluate(built, Snapshot{FilesChanged: 2})
+	if len(suggestions) != 1 || suggestions[0].ID != FilesChangedRule {
+		t.Fatalf("Expected files_changed suggestion with overridden threshold, got %v", suggestions)
+	}
+	
+	if suggestions[0].Metrics["threshold"] != 2 {
+		t.Errorf("Expected threshold metric 2, got %d", suggestions[0].Metrics["threshold"])
+	}
+}
+
+func TestBuildRejectsInvalidSettings(t *testing.T) {
+	cases := map[string]config.RuleSetting{
+		"no_such_rule":   {},
+		FilesChangedRule: {Params: map[string]int{"limit": 3}},
+		LinesChangedRule: {Params: map[string]int{"threshold": 0}},
+	}
+	
+	for id, setting := range cases {
+		cfg := config.DefaultConfig()
+		cfg.RuleSettings = map[string]config.RuleSetting{id: setting}
+		
+		if _, err := Build(cfg); err == nil {
+			t.Errorf("Expected error for rule setting %s: %+v", id, setting)
+		}
+	}
+}
+
+func TestThresholdRules(t *testing.T) {
+	built, err := Build(config.DefaultConfig())
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	
+	now := time.Now()
+	snapshot := Snapshot{
+		FilesChanged:    12,
+		LinesAdded:      40,
+		LinesRemoved:    10,
+		LastCommit:      now.Add(-45 * time.Minute),
+		UnpushedCommits: 3,
+		Now:             now,
+	}
+	
+	got := make(map[string]Suggestion)
+	for _, suggestion := range Evaluate(built, snapshot) {
+		got[suggestion.ID] = suggestion
+	}
+	
+	if got[FilesChangedRule].Severity != SeverityWarning {
+		t.Errorf("Expected warning severity for files far over threshold, got %q", got[FilesChangedRule].Severity)
+	}
+	
+	if _, ok := got[LinesChangedRule]; ok {
+		t.Error("Lines rule should not fire below threshold")
+	}
+	
+	if got[TimeSinceCommitRule].Metrics["minutes_since_commit"] != 45 {
+		t.Errorf("Expected 45 minutes since commit, got %v", got[TimeSinceCommitRule].Metrics)
+	}
+	
+	if got[UnpushedCommitsRule].Action != ActionPush {
+		t.Error("Unpushed commits rule should suggest a push")
+	}
+	
+	if len(got[FilesChangedRule].Reasons) == 0 {
+		t.Error("Suggestions should explain why they fired")
+	}
+	
+	snapshot.LastCommit = time.Time{}
+	for _, suggestion := range Evaluate(built, snapshot) {
+		if suggestion.ID == TimeSinceCommitRule {
+			t.Error("Time rule should not fire without a known last commit")
+		}
+	}
+}
+
+func TestScopedOverrides(t *testing.T) {
+	cfg := config.DefaultConfig()
+	disabled := false
+	cfg.Overrides = []config.Override{
+		{Paths: []string{"docs/"}, RuleSettings: map[string]config.RuleSetting{
+			LinesChangedRule: {Enabled: &disabled},
+			FilesChangedRule: {Enabled: &disabled},
+		}},
+		{Name: "billing", Paths: []string{"pkg/billing/**"}, RuleSettings: map[string]config.RuleSetting{
+			LinesChangedRule: {Params: map[string]int{"threshold": 30}},
+		}},
+		{Branches: []string{"release/*"}, RuleSettings: map[string]config.RuleSetting{
+			UnpushedCommitsRule: {Params: map[string]int{"threshold": 1}},
+		}},
+	}
+	
+	scoped, err := BuildScoped(cfg)
+	if err != nil {
+		t.Fatalf("BuildScoped failed: %v", err)
+	}
+	
+	snapshot := Snapshot{
+		Branch:       "main",
+		ChangedFiles: []string{"docs/guide.md", "pkg/billing/invoice.go", "main.go"},
+		Files: map[string]FileChange{
+			"docs/guide.md":          {Added: 900},
+			"pkg/billing/invoice.go": {Added: 25, Removed: 10},
+			"main.go":                {Added: 5},
+		},
+		UnpushedCommits: 1,
+	}
+	
+	evaluations := scoped.Evaluate(snapshot)
+	if len(evaluations) != 1 {
+		t.Fatalf("Expected only the billing lines suggestion, got %+v", evaluations)
+	}
+	
+	suggestion := evaluations[0].Suggestion
+	if suggestion.ID != LinesChangedRule || suggestion.Scope != "billing" || suggestion.Value != 35 {
+		t.Errorf("Expected lines_changed in billing scope with 35 lines, got %+v", suggestion)
+	}
+	if suggestion.Key() != "lines_changed@billing" {
+		t.Errorf("Scoped suggestions should have a scoped key, got %s", suggestion.Key())
+	}
+	if !strings.Contains(suggestion.Reasons[0], "in billing") {
+		t.Errorf("Reason should name the scope, got %q", suggestion.Reasons[0])
+	}
+	
+	snapshot.Branch = "release/2.0"
+	evaluations = scoped.Evaluate(snapshot)
+	found := false
+	for _, evaluation := range evaluations {
+		if evaluation.ID == UnpushedCommitsRule {
+			found = evaluation.Scope == "branch release/*"
+		}
+	}
+	if !found {
+		t.Errorf("Release branch should trigger a stricter push suggestion, got %+v", evaluations)
+	}
+	
+	snapshot.Branch = "main"
+	snapshot.Now = time.Now()
+	snapshot.LastCommit = snapshot.Now.Add(-3 * time.Hour)
+	var elapsed []Evaluation
+	for _, evaluation := range scoped.Evaluate(snapshot) {
+		if evaluation.ID == TimeSinceCommitRule {
+			elapsed = append(elapsed, evaluation)
+		}
+	}
+	if len(elapsed) != 1 || elapsed[0].Scope != "" {
+		t.Errorf("Time since commit should be evaluated once for the whole repository, got %+v", elapsed)
+	}
+	
+	cfg.Overrides = []config.Override{{Name: "empty"}}
+	if _, err := BuildScoped(cfg); err == nil {
+		t.Error("Override without paths or branches should be rejected")
+	}
+	
+	cfg.Overrides = []config.Override{{Paths: []string{"docs/"}, RuleSettings: map[string]config.RuleSetting{"no_such_rule": {}}}}
+	if _, err := BuildScoped(cfg); err == nil {
+		t.Error("Override with unknown rule should be rejected")
+	}
+}
+
+func TestSecretsRule(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Overrides = []config.Override{{Name: "fixtures", Paths: []string{"testdata/"}}}
+	
+	scoped, err := BuildScoped(cfg)
+	if err != nil {
+		t.Fatalf("BuildScoped failed: %v", err)
+	}
+	
+	snapshot := Snapshot{
+		ChangedFiles: []string{"main.go", "testdata/key.pem"},
+		Secrets: []secrets.Finding{
+			{RuleID: "aws-access-key-id", Description: "AWS access key ID", Path: "main.go", Line: 3, Redacted: "AKIA********", Fingerprint: "0123456789abcdef"},
+			{RuleID: "private-key", Description: "private key", Path: "testdata/key.pem", Line: 1, Fingerprint: "fedcba9876543210"},
+		},
+	}
+	
+	var found []Suggestion
+	for _, evaluation := range scoped.Evaluate(snapshot) {
+		if evaluation.ID == SecretsRule {
+			found = append(found, evaluation.Suggestion)
+		}
+	}
+	
+	if len(found) != 2 {
+		t.Fatalf("Expected a secrets suggestion per scope, got %+v", found)
+	}
+	
+	root := found[0]
+	if root.Action != ActionReview || root.Severity != SeverityHigh || root.Value != 1 || root.Scope != "" {
+		t.Errorf("Unexpected root suggestion: %+v", root)
+	}
+	if len(root.Reasons) != 1 || !strings.Contains(root.Reasons[0], "main.go:3: possible AWS access key ID") {
+		t.Errorf("Unexpected reasons: %v", root.Reasons)
+	}
+	
+	if found[1].Scope != "fixtures" || found[1].Value != 1 {
+		t.Errorf("Finding under an override should be reported in its scope, got %+v", found[1])
+	}
+	
+	disabled := false
+	cfg.RuleSettings = map[string]config.RuleSetting{SecretsRule: {Enabled: &disabled}}
+	cfg.Overrides = nil
+	built, _ := Build(cfg)
+	if len(Evaluate(built, snapshot)) != 0 {
+		t.Error("Disabled secrets rule should not report")
+	}
+}
@@ -0,0 +1,258 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/ignore"
+)
+
+var repoWideRules = map[string]bool{
+	TimeSinceCommitRule: true,
+	UnpushedCommitsRule: true,
+}
+
+type FileChange struct {
+	Added   int
+	Removed int
+}
+
+type Evaluation struct {
+	Suggestion
+	Config *config.Config
+}
+
+type Scoped struct {
+	base      *config.Config
+	overrides []scopeOverride
+}
+
+type scopeOverride struct {
+	name     string
+	paths    *ignore.PathMatcher
+	branches []string
+	settings map[string]config.RuleSetting
+}
+
+func BuildScoped(cfg *config.Config) (*Scoped, error) {
+	if _, err := Build(cfg); err != nil {
+		return nil, err
+	}
+	
+	scoped := &Scoped{base: cfg}
+	for i, override := range cfg.Overrides {
+		if len(override.Paths) == 0 && len(override.Branches) == 0 {
+			return nil, fmt.Errorf("override %d must set paths or branches", i+1)
+		}
+		name := ScopeName(override)
+		
+		o := scopeOverride{
+			name:     name,
+			branches: override.Branches,
+			settings: override.RuleSettings,
+		}
+		
+		if len(override.Paths) > 0 {
+			matcher, err := ignore.NewPathMatcher(override.Paths)
+			if err != nil {
+				return nil, fmt.Errorf("invalid override %s: %w", name, err)
+			}
+			o.paths = matcher
+		}
+		
+		for _, branch := range override.Branches {
+			if _, err := path.Match(branch, ""); err != nil {
+				return nil, fmt.Errorf("invalid override %s: invalid branch pattern: %q", name, branch)
+			}
+		}
+		
+		if _, err := Build(withSettings(cfg, o.settings)); err != nil {
+			return nil, fmt.Errorf("invalid override %s: %w", name, err)
+		}
+		
+		scoped.overrides = append(scoped.overrides, o)
+	}
+	
+	return scoped, nil
+}
+
+func ScopeName(override config.Override) string {
+	switch {
+	case override.Name != "":
+		return override.Name
+	case len(override.Paths) > 0:
+		return strings.Join(override.Paths, ", ")
+	}
+	
+	return "branch " + strings.Join(override.Branches, ", ")
+}
+
+func (s *Scoped) PathScoped() bool {
+	for _, o := range s.overrides {
+		if o.paths != nil {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func (s *Scoped) Evaluate(snapshot Snapshot) []Evaluation {
+	base := s.base
+	var branchScopes []string
+	for _, o := range s.overrides {
+		if o.paths == nil && o.matchBranch(snapshot.Branch) {
+			base = withSettings(base, o.settings)
+			branchScopes = append(branchScopes, o.name)
+		}
+	}
+	
+	claimed := make(map[int][]string)
+	var unclaimed []string
+	for _, file := range snapshot.ChangedFiles {
+		owner := -1
+		for i, o := range s.overrides {
+			if o.paths != nil && o.matchBranch(snapshot.Branch) && o.paths.Match(file) {
+				owner = i
+				break
+			}
+		}
+		
+		if owner < 0 {
+			unclaimed = append(unclaimed, file)
+			continue
+		}
+		claimed[owner] = append(claimed[owner], file)
+	}
+	
+	rootSnapshot := snapshot
+	if len(claimed) > 0 {
+		rootSnapshot = snapshot.restrict(unclaimed)
+	}
+	evaluations := evaluateScope(base, strings.Join(branchScopes, ", "), rootSnapshot, false)
+	
+	for i, o := range s.overrides {
+		files, ok := claimed[i]
+		if !ok {
+			continue
+		}
+		
+		evaluations = append(evaluations, evaluateScope(withSettings(base, o.settings), o.name, snapshot.restrict(files), true)...)
+	}
+	
+	return evaluations
+}
+
+func evaluateScope(cfg *config.Config, scope string, snapshot Snapshot, pathScope bool) []Evaluation {
+	built, err := Build(cfg)
+	if err != nil {
+		return nil
+	}
+	
+	if pathScope {
+		var perPath []Rule
+		for _, rule := range built {
+			if !repoWideRules[rule.ID()] {
+				perPath = append(perPath, rule)
+			}
+		}
+		built = perPath
+	}
+	
+	var evaluations []Evaluation
+	for _, suggestion := range Evaluate(built, snapshot) {
+		if scope != "" {
+			suggestion.Scope = scope
+			for i, reason := range suggestion.Reasons {
+				suggestion.Reasons[i] = fmt.Sprintf("%s in %s", reason, scope)
+			}
+		}
+		evaluations = append(evaluations, Evaluation{Suggestion: suggestion, Config: cfg})
+	}
+	
+	return evaluations
+}
+
+func (o scopeOverride) matchBranch(branch string) bool {
+	if len(o.branches) == 0 {
+		return true
+	}
+	
+	for _, pattern := range o.branches {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func (s Snapshot) restrict(files []string) Snapshot {
+	restricted := s
+	restricted.ChangedFiles = files
+	restricted.FilesChanged = len(files)
+	restricted.LinesAdded = 0
+	restricted.LinesRemoved = 0
+	
+	included := make(map[string]bool, len(files))
+	for _, file := range files {
+		included[file] = true
+		change := s.Files[file]
+		restricted.LinesAdded += change.Added
+		restricted.LinesRemoved += change.Removed
+	}
+	
+	restricted.Secrets = nil
+	for _, finding := range s.Secrets {
+		if included[finding.Path] {
+			restricted.Secrets = append(restricted.Secrets, finding)
+		}
+	}
+	
+	if len(files) == 0 {
+		restricted.LastCommit = time.Time{}
+	}
+	
+	return restricted
+}
+
+func withSettings(cfg *config.Config, settings map[string]config.RuleSetting) *config.Config {
+	if len(settings) == 0 {
+		return cfg
+	}
+	
+	merged := *cfg
+	merged.RuleSettings = make(map[string]config.RuleSetting, len(cfg.RuleSettings)+len(settings))
+	for id, setting := range cfg.RuleSettings {
+		merged.RuleSettings[id] = setting
+	}
+	
+	for id, override := range settings {
+		setting := merged.RuleSettings[id]
+		if override.Enabled != nil {
+			setting.Enabled = override.Enabled
+		}
+		if override.Urgent != nil {
+			setting.Urgent = override.Urgent
+		}
+		if override.CooldownMinutes != nil {
+			setting.CooldownMinutes = override.CooldownMinutes
+		}
+		
+		params := make(map[string]int, len(setting.Params)+len(override.Params))
+		for name, value := range setting.Params {
+			params[name] = value
+		}
+		for name, value := range override.Params {
+			params[name] = value
+		}
+		setting.Params = params
+		
+		merged.RuleSettings[id] = setting
+	}
+	
+	return &merged
+}
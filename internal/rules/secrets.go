@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"fmt"
+
+	"gitsentry/internal/config"
+)
+
+const (
+	SecretsRule = "secrets"
+	
+	maxSecretReasons = 5
+)
+
+func init() {
+	Register(SecretsRule, func(cfg *config.Config, params Params) (Rule, error) {
+		if err := params.Check(); err != nil {
+			return nil, err
+		}
+		
+		return secretsRule{}, nil
+	})
+}
+
+type secretsRule struct{}
+
+func (secretsRule) ID() string {
+	return SecretsRule
+}
+
+func (secretsRule) Action() Action {
+	return ActionReview
+}
+
+func (secretsRule) Evaluate(snapshot Snapshot) *Suggestion {
+	if len(snapshot.Secrets) == 0 {
+		return nil
+	}
+	
+	var reasons []string
+	for i, finding := range snapshot.Secrets {
+		if i == maxSecretReasons {
+			reasons = append(reasons, fmt.Sprintf("and %d more", len(snapshot.Secrets)-maxSecretReasons))
+			break
+		}
+		reasons = append(reasons, finding.String())
+	}
+	
+	return &Suggestion{
+		ID:       SecretsRule,
+		Action:   ActionReview,
+		Severity: SeverityHigh,
+		Value:    len(snapshot.Secrets),
+		Reasons:  reasons,
+		Metrics: map[string]int{
+			"secrets": len(snapshot.Secrets),
+		},
+	}
+}
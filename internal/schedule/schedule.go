@@ -0,0 +1,182 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitsentry/internal/config"
+)
+
+var dayNames = map[string][]time.Weekday{
+	"sun":      {time.Sunday},
+	"mon":      {time.Monday},
+	"tue":      {time.Tuesday},
+	"wed":      {time.Wednesday},
+	"thu":      {time.Thursday},
+	"fri":      {time.Friday},
+	"sat":      {time.Saturday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+type window struct {
+	days [7]bool
+	from int
+	to   int
+}
+
+type Schedule struct {
+	location  *time.Location
+	windows   []window
+	focusIdle time.Duration
+}
+
+func New(cfg config.Schedule) (*Schedule, error) {
+	s := &Schedule{
+		location:  time.Local,
+		focusIdle: time.Duration(cfg.FocusIdleMinutes) * time.Minute,
+	}
+	
+	if cfg.Timezone != "" {
+		location, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+		s.location = location
+	}
+	
+	if cfg.FocusIdleMinutes < 0 {
+		return nil, fmt.Errorf("focus_idle_minutes must not be negative")
+	}
+	
+	for i, quiet := range cfg.QuietHours {
+		w, err := parseWindow(quiet)
+		if err != nil {
+			return nil, fmt.Errorf("quiet_hours[%d]: %w", i, err)
+		}
+		s.windows = append(s.windows, w)
+	}
+	
+	return s, nil
+}
+
+func (s *Schedule) InQuietHours(now time.Time) bool {
+	local := now.In(s.location)
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+	
+	for _, w := range s.windows {
+		if w.from <= w.to {
+			if w.days[today] && minute >= w.from && minute < w.to {
+				return true
+			}
+			continue
+		}
+		
+		if w.days[today] && minute >= w.from {
+			return true
+		}
+		if w.days[yesterday] && minute < w.to {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func (s *Schedule) InFocus(now, lastActivity time.Time) bool {
+	if s.focusIdle <= 0 || lastActivity.IsZero() {
+		return false
+	}
+	
+	return now.Sub(lastActivity) < s.focusIdle
+}
+
+func (s *Schedule) Hold(now, lastActivity time.Time) (bool, string) {
+	if s.InQuietHours(now) {
+		return true, "quiet hours"
+	}
+	
+	if s.InFocus(now, lastActivity) {
+		return true, "focus mode"
+	}
+	
+	return false, ""
+}
+
+func parseWindow(quiet config.QuietHours) (window, error) {
+	var w window
+	
+	if len(quiet.Days) == 0 {
+		for i := range w.days {
+			w.days[i] = true
+		}
+	}
+	
+	for _, day := range quiet.Days {
+		name := strings.ToLower(strings.TrimSpace(day))
+		if len(name) > 3 && name != "weekdays" && name != "weekends" {
+			name = name[:3]
+		}
+		
+		days, ok := dayNames[name]
+		if !ok {
+			return window{}, fmt.Errorf("unknown day %q", day)
+		}
+		for _, d := range days {
+			w.days[d] = true
+		}
+	}
+	
+	from, err := parseClock(quiet.From)
+	if err != nil {
+		return window{}, fmt.Errorf("invalid from: %w", err)
+	}
+	if from == 24*60 {
+		return window{}, fmt.Errorf("invalid from: 24:00 is only allowed as an end time")
+	}
+	
+	to, err := parseClock(quiet.To)
+	if err != nil {
+		return window{}, fmt.Errorf("invalid to: %w", err)
+	}
+	
+	if from == to {
+		return window{}, fmt.Errorf("from and to must differ")
+	}
+	
+	w.from = from
+	w.to = to
+	
+	return w, nil
+}
+
+func parseClock(value string) (int, error) {
+	hours, minutes, found := strings.Cut(strings.TrimSpace(value), ":")
+	if !found {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	
+	m, err := strconv.Atoi(minutes)
+	if err != nil || len(minutes) != 2 {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	
+	if h == 24 && m == 0 {
+		return 24 * 60, nil
+	}
+	
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("time out of range: %q", value)
+	}
+	
+	return h*60 + m, nil
+}
@@ -0,0 +1,104 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"gitsentry/internal/config"
+)
+
+func at(t *testing.T, value string) time.Time {
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, time.UTC)
+	if err != nil {
+		t.Fatalf("Bad test time %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestQuietHours(t *testing.T) {
+	s, err := New(config.Schedule{
+		Timezone: "UTC",
+		QuietHours: []config.QuietHours{
+			{Days: []string{"weekdays"}, From: "22:00", To: "07:30"},
+			{Days: []string{"Saturday", "sun"}, From: "00:00", To: "24:00"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build schedule: %v", err)
+	}
+	
+	cases := map[string]bool{
+		"2026-10-12 21:59": false, // Monday evening
+		"2026-10-12 22:00": true,  // Monday night
+		"2026-10-13 07:00": true,  // Tuesday morning, carried over from Monday
+		"2026-10-13 07:30": false,
+		"2026-10-13 12:00": false,
+		"2026-10-17 12:00": true,  // Saturday
+		"2026-10-19 03:00": false, // Monday morning, Sunday's window ends at midnight
+		"2026-10-18 23:59": true,  // Sunday
+	}
+	
+	for value, expected := range cases {
+		if got := s.InQuietHours(at(t, value)); got != expected {
+			t.Errorf("InQuietHours(%s) = %t, expected %t", value, got, expected)
+		}
+	}
+}
+
+func TestQuietHoursTimezone(t *testing.T) {
+	s, err := New(config.Schedule{
+		Timezone:   "Asia/Tokyo",
+		QuietHours: []config.QuietHours{{From: "09:00", To: "10:00"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build schedule: %v", err)
+	}
+	
+	if !s.InQuietHours(at(t, "2026-10-14 00:30")) {
+		t.Error("09:30 in Tokyo should be quiet")
+	}
+	
+	if s.InQuietHours(at(t, "2026-10-14 09:30")) {
+		t.Error("18:30 in Tokyo should not be quiet")
+	}
+}
+
+func TestFocusMode(t *testing.T) {
+	s, err := New(config.Schedule{FocusIdleMinutes: 10})
+	if err != nil {
+		t.Fatalf("Failed to build schedule: %v", err)
+	}
+	
+	now := time.Now()
+	
+	if hold, reason := s.Hold(now, now.Add(-2*time.Minute)); !hold || reason != "focus mode" {
+		t.Errorf("Recent activity should hold suggestions, got %t %q", hold, reason)
+	}
+	
+	if hold, _ := s.Hold(now, now.Add(-15*time.Minute)); hold {
+		t.Error("Suggestions should be released after the idle period")
+	}
+	
+	disabled, _ := New(config.Schedule{})
+	if hold, _ := disabled.Hold(now, now); hold {
+		t.Error("Empty schedule should never hold suggestions")
+	}
+}
+
+func TestInvalidSchedule(t *testing.T) {
+	invalid := []config.Schedule{
+		{Timezone: "Mars/Olympus"},
+		{QuietHours: []config.QuietHours{{Days: []string{"someday"}, From: "09:00", To: "10:00"}}},
+		{QuietHours: []config.QuietHours{{From: "9", To: "10:00"}}},
+		{QuietHours: []config.QuietHours{{From: "25:00", To: "10:00"}}},
+		{QuietHours: []config.QuietHours{{From: "24:00", To: "08:00"}}},
+		{QuietHours: []config.QuietHours{{From: "10:00", To: "10:00"}}},
+		{FocusIdleMinutes: -1},
+	}
+	
+	for _, cfg := range invalid {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("Expected error for schedule %+v", cfg)
+		}
+	}
+}
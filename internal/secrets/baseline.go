@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gitsentry/internal/security"
+)
+
+const (
+	BaselineFile    = "secrets-baseline.json"
+	baselineVersion = 1
+)
+
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	Path        string `json:"path"`
+	Line        int    `json:"line"`
+}
+
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+	index    map[string]bool
+}
+
+func BaselinePath(gitsentryDir string) string {
+	return filepath.Join(gitsentryDir, BaselineFile)
+}
+
+func NewBaseline(findings []Finding) *Baseline {
+	baseline := &Baseline{Version: baselineVersion, Findings: []BaselineEntry{}}
+	for _, finding := range findings {
+		baseline.Findings = append(baseline.Findings, BaselineEntry{
+			Fingerprint: finding.Fingerprint,
+			Rule:        finding.RuleID,
+			Path:        finding.Path,
+			Line:        finding.Line,
+		})
+	}
+	
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	baseline.reindex()
+	
+	return baseline
+}
+
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := security.SecureReadFile(path)
+	if os.IsNotExist(err) {
+		return NewBaseline(nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("invalid secrets baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported secrets baseline version %d in %s", baseline.Version, path)
+	}
+	baseline.reindex()
+	
+	return &baseline, nil
+}
+
+func (b *Baseline) Contains(fingerprint string) bool {
+	return b != nil && b.index[fingerprint]
+}
+
+func (b *Baseline) reindex() {
+	b.index = make(map[string]bool, len(b.Findings))
+	for _, entry := range b.Findings {
+		b.index[entry.Fingerprint] = true
+	}
+}
+
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	
+	return security.SecureWriteFile(path, append(data, '\n'))
+}
@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+type Rule struct {
+	ID          string
+	Description string
+	Pattern     *regexp.Regexp
+	SecretGroup int
+	MinEntropy  float64
+	NeedsDigit  bool
+}
+
+var Builtin = []Rule{
+	{
+		ID:          "aws-access-key-id",
+		Description: "AWS access key ID",
+		Pattern:     regexp.MustCompile(`\b((?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[A-Z2-7]{16})\b`),
+		SecretGroup: 1,
+	},
+	{
+		ID:          "aws-secret-access-key",
+		Description: "AWS secret access key",
+		Pattern:     regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]+\s*["']?([A-Za-z0-9/+=]{40})`),
+		SecretGroup: 1,
+		MinEntropy:  3.5,
+	},
+	{
+		ID:          "private-key",
+		Description: "private key",
+		Pattern:     regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`),
+	},
+	{
+		ID:          "jwt",
+		Description: "JSON web token",
+		Pattern:     regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+	},
+	{
+		ID:          "generic-secret",
+		Description: "high-entropy secret assignment",
+		Pattern:     regexp.MustCompile(`(?i)(?:secret|token|passw(?:or)?d|pwd|api_?key|access_?key|auth_?key|credentials?)[\w.-]*["']?\s*(?::=|=>|[:=])\s*["'` + "`" + `]?([A-Za-z0-9+/=_.~!@#$%^&*-]{16,})`),
+		SecretGroup: 1,
+		MinEntropy:  3.5,
+		NeedsDigit:  true,
+	},
+}
+
+func (r Rule) accept(secret string) bool {
+	if r.NeedsDigit && !strings.ContainsAny(secret, "0123456789") {
+		return false
+	}
+	
+	return r.MinEntropy == 0 || Entropy(secret) >= r.MinEntropy
+}
+
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	
+	return entropy
+}
@@ -0,0 +1,164 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitsentry/internal/config"
+	"gitsentry/internal/ignore"
+)
+
+const (
+	MaxFileSize    = 1024 * 1024
+	binarySniffLen = 8000
+)
+
+type Finding struct {
+	RuleID      string
+	Description string
+	Path        string
+	Line        int
+	Column      int
+	Redacted    string
+	Fingerprint string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: possible %s %s (fingerprint %s)", f.Path, f.Line, f.Description, f.Redacted, f.Fingerprint)
+}
+
+type Scanner struct {
+	rules      []Rule
+	allowPaths *ignore.PathMatcher
+	allowed    map[string]bool
+}
+
+func NewScanner(settings config.Secrets) (*Scanner, error) {
+	allowPaths, err := ignore.NewPathMatcher(settings.AllowPaths)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets.allow_paths: %w", err)
+	}
+	
+	allowed := make(map[string]bool, len(settings.AllowFingerprints))
+	for _, fingerprint := range settings.AllowFingerprints {
+		allowed[fingerprint] = true
+	}
+	
+	return &Scanner{rules: Builtin, allowPaths: allowPaths, allowed: allowed}, nil
+}
+
+func (s *Scanner) ScanFile(root, path string) ([]Finding, error) {
+	if s.allowPaths.Match(path) {
+		return nil, nil
+	}
+	
+	full := filepath.Join(root, filepath.FromSlash(path))
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() || info.Size() > MaxFileSize {
+		return nil, nil
+	}
+	
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.Scan(path, data), nil
+}
+
+func (s *Scanner) Scan(path string, data []byte) []Finding {
+	if s.allowPaths.Match(path) || len(data) > MaxFileSize {
+		return nil
+	}
+	
+	head := data
+	if len(head) > binarySniffLen {
+		head = head[:binarySniffLen]
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		return nil
+	}
+	
+	var findings []Finding
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), MaxFileSize)
+	for number := 1; scanner.Scan(); number++ {
+		line := scanner.Text()
+		var spans [][2]int
+		for _, rule := range s.rules {
+			for _, match := range rule.Pattern.FindAllStringSubmatchIndex(line, -1) {
+				start, end := match[2*rule.SecretGroup], match[2*rule.SecretGroup+1]
+				if start < 0 {
+					continue
+				}
+				
+				secret := line[start:end]
+				if overlaps(spans, start, end) || !rule.accept(secret) {
+					continue
+				}
+				spans = append(spans, [2]int{start, end})
+				
+				findings = append(findings, Finding{
+					RuleID:      rule.ID,
+					Description: rule.Description,
+					Path:        path,
+					Line:        number,
+					Column:      start + 1,
+					Redacted:    redact(secret),
+					Fingerprint: fingerprint(rule.ID, path, secret),
+				})
+			}
+		}
+	}
+	
+	return findings
+}
+
+func (s *Scanner) Filter(findings []Finding, baseline *Baseline) []Finding {
+	var kept []Finding
+	for _, finding := range findings {
+		if s.allowed[finding.Fingerprint] || baseline.Contains(finding.Fingerprint) {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	
+	return kept
+}
+
+func overlaps(spans [][2]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	
+	return false
+}
+
+func fingerprint(ruleID, path, secret string) string {
+	sum := sha256.Sum256([]byte(ruleID + "\x00" + path + "\x00" + secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+func redact(secret string) string {
+	if strings.HasPrefix(secret, "-----") {
+		return secret
+	}
+	
+	keep := len(secret) / 5
+	if keep > 4 {
+		keep = 4
+	}
+	
+	return secret[:keep] + strings.Repeat("*", 8)
+}
@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gitsentry/internal/config"
+)
+
+type trackedFile struct {
+	modTime  time.Time
+	size     int64
+	findings []Finding
+}
+
+type Tracker struct {
+	mu           sync.Mutex
+	root         string
+	baselinePath string
+	scanner      *Scanner
+	baseline     *Baseline
+	baselineMod  time.Time
+	files        map[string]trackedFile
+}
+
+func NewTracker(root, gitsentryDir string, settings config.Secrets) (*Tracker, error) {
+	scanner, err := NewScanner(settings)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &Tracker{
+		root:         root,
+		baselinePath: BaselinePath(gitsentryDir),
+		scanner:      scanner,
+		files:        make(map[string]trackedFile),
+	}, nil
+}
+
+func (t *Tracker) Update(paths []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	
+	current := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		current[path] = true
+		
+		info, err := os.Stat(filepath.Join(t.root, filepath.FromSlash(path)))
+		if err != nil {
+			delete(t.files, path)
+			continue
+		}
+		
+		if tracked, ok := t.files[path]; ok && tracked.modTime.Equal(info.ModTime()) && tracked.size == info.Size() {
+			continue
+		}
+		
+		findings, err := t.scanner.ScanFile(t.root, path)
+		if err != nil {
+			delete(t.files, path)
+			continue
+		}
+		t.files[path] = trackedFile{modTime: info.ModTime(), size: info.Size(), findings: findings}
+	}
+	
+	for path := range t.files {
+		if !current[path] {
+			delete(t.files, path)
+		}
+	}
+}
+
+func (t *Tracker) Findings() []Finding {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	
+	t.reloadBaseline()
+	
+	var findings []Finding
+	for _, tracked := range t.files {
+		findings = append(findings, t.scanner.Filter(tracked.findings, t.baseline)...)
+	}
+	
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	
+	return findings
+}
+
+func (t *Tracker) reloadBaseline() {
+	info, err := os.Stat(t.baselinePath)
+	if err != nil {
+		t.baseline, t.baselineMod = nil, time.Time{}
+		return
+	}
+	
+	if info.ModTime().Equal(t.baselineMod) {
+		return
+	}
+	t.baselineMod = info.ModTime()
+	
+	baseline, err := LoadBaseline(t.baselinePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "GitSentry: %v\n", err)
+		return
+	}
+	t.baseline = baseline
+}
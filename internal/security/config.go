@@ -3,6 +3,7 @@ package security
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 type ConfigValidator struct {
@@ -28,6 +29,43 @@ func NewConfigValidator() *ConfigValidator {
 			"max_lines_changed":      true,
 			"max_minutes_since_commit": true,
 			"max_unpushed_commits":   true,
+			"monitor":                true,
+			"debounce_ms":            true,
+			"burst_threshold":        true,
+			"rule_settings":          true,
+			"notifiers":              true,
+			"suggestions":            true,
+			"cooldown_minutes":       true,
+			"growth_percent":         true,
+			"schedule":               true,
+			"timezone":               true,
+			"quiet_hours":            true,
+			"focus_idle_minutes":     true,
+			"natural_break":          true,
+			"pause_seconds":          true,
+			"overrides":              true,
+			"commit_lint":            true,
+			"types":                  true,
+			"scopes":                 true,
+			"require_scope":          true,
+			"max_subject_length":     true,
+			"max_body_line_length":   true,
+			"ticket_pattern":         true,
+			"commit_gate":            true,
+			"mode":                   true,
+			"max_files":              true,
+			"max_lines":              true,
+			"max_file_size_kb":       true,
+			"allow_binary":           true,
+			"push_policy":            true,
+			"protected_branches":     true,
+			"shared_branches":        true,
+			"allow_wip":              true,
+			"require_signoff":        true,
+			"max_commits":            true,
+			"secrets":                true,
+			"allow_paths":            true,
+			"allow_fingerprints":     true,
 		},
 		rules: map[string]ValidationRule{
 			"max_files_changed": {
@@ -50,6 +88,70 @@ func NewConfigValidator() *ConfigValidator {
 				MinValue: intPtr(1),
 				MaxValue: intPtr(100),
 			},
+			"debounce_ms": {
+				Required: false,
+				MinValue: intPtr(10),
+				MaxValue: intPtr(60000),
+			},
+			"burst_threshold": {
+				Required: false,
+				MinValue: intPtr(1),
+				MaxValue: intPtr(100000),
+			},
+			"cooldown_minutes": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(1440),
+			},
+			"growth_percent": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(1000),
+			},
+			"focus_idle_minutes": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(1440),
+			},
+			"pause_seconds": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(3600),
+			},
+			"max_subject_length": {
+				Required: false,
+				MinValue: intPtr(20),
+				MaxValue: intPtr(200),
+			},
+			"max_body_line_length": {
+				Required: false,
+				MinValue: intPtr(20),
+				MaxValue: intPtr(500),
+			},
+			"max_files": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(1000),
+			},
+			"max_lines": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(100000),
+			},
+			"max_file_size_kb": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(1048576),
+			},
+			"max_commits": {
+				Required: false,
+				MinValue: intPtr(0),
+				MaxValue: intPtr(10000),
+			},
+			"mode": {
+				Required: false,
+				AllowedValues: []string{"off", "warn", "block"},
+			},
 			"commit_message_format": {
 				Required: false,
 				AllowedValues: []string{"conventional", "simple"},
@@ -126,7 +228,42 @@ func ValidateConfigStruct(config interface{}) error {
 	validator := NewConfigValidator()
 	
 	configMap := structToMap(config)
-	return validator.ValidateConfig(configMap)
+	if err := validator.ValidateConfig(configMap); err != nil {
+		return err
+	}
+	
+	for _, section := range sectionMaps(config) {
+		for field, rule := range validator.rules {
+			if _, exists := section[field]; !exists {
+				continue
+			}
+			if err := validator.validateField(section, field, rule); err != nil {
+				return err
+			}
+		}
+	}
+	
+	return nil
+}
+
+func sectionMaps(obj interface{}) []map[string]interface{} {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	
+	var sections []map[string]interface{}
+	for i := 0; i < v.NumField(); i++ {
+		value := v.Field(i)
+		if value.Kind() != reflect.Struct || !value.CanInterface() {
+			continue
+		}
+		
+		sections = append(sections, structToMap(value.Interface()))
+		sections = append(sections, sectionMaps(value.Interface())...)
+	}
+	
+	return sections
 }
 
 func structToMap(obj interface{}) map[string]interface{} {
@@ -146,7 +283,7 @@ func structToMap(obj interface{}) map[string]interface{} {
 			continue
 		}
 		
-		tag := field.Tag.Get("yaml")
+		tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
 		if tag == "" {
 			tag = field.Name
 		}
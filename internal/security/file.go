@@ -7,8 +7,10 @@ import (
 )
 
 const (
-	SecureFileMode = 0644
-	SecureDirMode  = 0755
+	SecureFileMode  = 0644
+	SecureDirMode   = 0755
+	PrivateFileMode = 0600
+	ExecFileMode    = 0755
 )
 
 func SecureWriteFile(path string, data []byte) error {
@@ -25,6 +27,78 @@ func SecureWriteFile(path string, data []byte) error {
 	return os.WriteFile(cleanPath, data, SecureFileMode)
 }
 
+func SecurePrivateWriteFile(path string, data []byte) error {
+	cleanPath, err := SanitizePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	
+	dir := filepath.Dir(cleanPath)
+	if err := os.MkdirAll(dir, SecureDirMode); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	
+	if err := os.WriteFile(cleanPath, data, PrivateFileMode); err != nil {
+		return err
+	}
+	
+	return os.Chmod(cleanPath, PrivateFileMode)
+}
+
+func SecureWriteExecutable(path string, data []byte) error {
+	cleanPath, err := SanitizePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	
+	dir := filepath.Dir(cleanPath)
+	if err := os.MkdirAll(dir, SecureDirMode); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	
+	if err := os.WriteFile(cleanPath, data, ExecFileMode); err != nil {
+		return err
+	}
+	
+	return os.Chmod(cleanPath, ExecFileMode)
+}
+
+func SecureWriteExecutableWithin(root, path string, data []byte) error {
+	if err := WithinRoot(root, path); err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	
+	if err := os.WriteFile(path, data, ExecFileMode); err != nil {
+		return err
+	}
+	
+	return os.Chmod(path, ExecFileMode)
+}
+
+func SecureAppendFile(path string, data []byte) error {
+	cleanPath, err := SanitizePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	
+	dir := filepath.Dir(cleanPath)
+	if err := os.MkdirAll(dir, SecureDirMode); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	
+	f, err := os.OpenFile(cleanPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, SecureFileMode)
+	if err != nil {
+		return err
+	}
+	
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	
+	return f.Close()
+}
+
 func SecureReadFile(path string) ([]byte, error) {
 	cleanPath, err := SanitizePath(path)
 	if err != nil {
@@ -34,6 +108,14 @@ func SecureReadFile(path string) ([]byte, error) {
 	return os.ReadFile(cleanPath)
 }
 
+func SecureReadFileWithin(root, path string) ([]byte, error) {
+	if err := WithinRoot(root, path); err != nil {
+		return nil, fmt.Errorf("invalid file path: %w", err)
+	}
+	
+	return os.ReadFile(path)
+}
+
 func SecureCreateDir(path string) error {
 	cleanPath, err := SanitizePath(path)
 	if err != nil {
@@ -64,4 +146,11 @@ func SecureRemoveFile(path string) error {
 	}
 	
 	return os.Remove(cleanPath)
-}
\ No newline at end of file
+}
+func SecureRemoveFileWithin(root, path string) error {
+	if err := WithinRoot(root, path); err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	
+	return os.Remove(path)
+}
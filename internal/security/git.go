@@ -15,6 +15,8 @@ var allowedGitCommands = map[string]bool{
 	"show":       true,
 	"ls-files":   true,
 	"rev-parse":  true,
+	"merge-base": true,
+	"cat-file":   true,
 }
 
 var allowedGitFlags = map[string]bool{
@@ -26,6 +28,23 @@ var allowedGitFlags = map[string]bool{
 	"--name-only":     true,
 	"--cached":        true,
 	"--short":         true,
+	"--numstat":       true,
+	"--no-renames":    true,
+	"-z":              true,
+	"--others":        true,
+	"--exclude-standard": true,
+	"--verify":        true,
+	"--quiet":         true,
+	"--untracked-files": true,
+	"--no-color":      true,
+	"--no-ext-diff":   true,
+	"--unified":       true,
+	"--find-renames":  true,
+	"--git-path":      true,
+	"--not":           true,
+	"--remotes":       true,
+	"--is-ancestor":   true,
+	"-s":              true,
 }
 
 func ValidateGitCommand(args []string) error {
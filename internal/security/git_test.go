@@ -11,6 +11,8 @@ func TestValidateGitCommand(t *testing.T) {
 		{"branch", "--show-current"},
 		{"remote"},
 		{"rev-list", "--count", "@{u}..HEAD"},
+		{"diff", "--numstat", "--no-renames", "-z", "HEAD"},
+		{"ls-files", "--others", "--exclude-standard", "-z"},
 	}
 	
 	for _, cmd := range validCommands {
@@ -2,8 +2,15 @@ package security
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+)
+
+var (
+	rootsMu      sync.RWMutex
+	allowedRoots = make(map[string]bool)
 )
 
 func SanitizePath(path string) (string, error) {
@@ -24,6 +31,63 @@ func SanitizePath(path string) (string, error) {
 	return cleaned, nil
 }
 
+func AllowRoot(root string) error {
+	if root == "" || !filepath.IsAbs(root) {
+		return fmt.Errorf("allowed root must be an absolute path: %s", root)
+	}
+	
+	rootsMu.Lock()
+	defer rootsMu.Unlock()
+	
+	allowedRoots[filepath.Clean(root)] = true
+	return nil
+}
+
+func WithinRoot(root, path string) error {
+	absRoot, err := resolvePath(root)
+	if err != nil {
+		return err
+	}
+	
+	absPath, err := resolvePath(path)
+	if err != nil {
+		return err
+	}
+	
+	rel, err := filepath.Rel(absRoot, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("path %s is outside %s", path, root)
+	}
+	
+	return nil
+}
+
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	
+	if _, lerr := os.Lstat(abs); lerr == nil {
+		return "", err
+	}
+	
+	dir, derr := filepath.EvalSymlinks(filepath.Dir(abs))
+	if derr != nil {
+		return "", err
+	}
+	
+	return filepath.Join(dir, filepath.Base(abs)), nil
+}
+
 func isAllowedAbsolutePath(path string) bool {
 	allowedPrefixes := []string{
 		"/tmp/",
@@ -38,6 +102,15 @@ func isAllowedAbsolutePath(path string) bool {
 		}
 	}
 	
+	rootsMu.RLock()
+	defer rootsMu.RUnlock()
+	
+	for root := range allowedRoots {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	
 	return false
 }
 
@@ -1,6 +1,8 @@
 package security
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -16,7 +18,7 @@ func TestSanitizePath(t *testing.T) {
 		{"path/../traversal", true},
 		{"clean/./path", false},
 	}
-
+	
 	for _, test := range tests {
 		result, err := SanitizePath(test.input)
 		
@@ -58,4 +60,68 @@ func TestValidateFilePath(t *testing.T) {
 			t.Errorf("Expected invalid path %s to fail validation", path)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestAllowRoot(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "home", "dev", "project")
+	inside := filepath.Join(root, ".gitsentry", "state.json")
+	sibling := root + "-other" + string(filepath.Separator) + "file"
+	
+	if err := ValidateFilePath(inside); err == nil {
+		t.Fatal("Absolute path should be rejected before its root is allowed")
+	}
+	
+	if err := AllowRoot("relative/root"); err == nil {
+		t.Error("Relative roots should be rejected")
+	}
+	
+	if err := AllowRoot(root); err != nil {
+		t.Fatalf("AllowRoot failed: %v", err)
+	}
+	
+	if err := ValidateFilePath(inside); err != nil {
+		t.Errorf("Path inside allowed root should pass: %v", err)
+	}
+	
+	if err := ValidateFilePath(sibling); err == nil {
+		t.Error("Sibling path sharing the root prefix should be rejected")
+	}
+}
+
+func TestWithinRoot(t *testing.T) {
+	tempDir := "test_within_root"
+	os.MkdirAll(filepath.Join(tempDir, "root", "nested"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "outside"), 0755)
+	defer os.RemoveAll(tempDir)
+	
+	root := filepath.Join(tempDir, "root")
+	os.WriteFile(filepath.Join(root, "nested", "a.yaml"), []byte("a"), 0644)
+	os.WriteFile(filepath.Join(tempDir, "outside", "b.yaml"), []byte("b"), 0644)
+	os.Symlink(filepath.Join("..", "outside", "b.yaml"), filepath.Join(root, "link.yaml"))
+	
+	if data, err := SecureReadFileWithin(root, filepath.Join(root, "nested", "a.yaml")); err != nil || string(data) != "a" {
+		t.Errorf("File inside the root should be readable, got %q (%v)", data, err)
+	}
+	
+	outside, _ := filepath.Abs(filepath.Join(tempDir, "outside", "b.yaml"))
+	for _, path := range []string{outside, filepath.Join(root, "..", "outside", "b.yaml"), filepath.Join(root, "link.yaml")} {
+		if _, err := SecureReadFileWithin(root, path); err == nil {
+			t.Errorf("Path %s should be rejected as outside the root", path)
+		}
+	}
+	
+	if err := SecureWriteExecutableWithin(root, filepath.Join(root, "nested", "hook"), []byte("#!/bin/sh\n")); err != nil {
+		t.Errorf("New file inside the root should be writable: %v", err)
+	}
+	
+	if err := SecureWriteExecutableWithin(root, filepath.Join(root, "link.yaml"), []byte("x")); err == nil {
+		t.Error("Writing through a symlink that leaves the root should be rejected")
+	}
+	if data, _ := os.ReadFile(outside); string(data) != "b" {
+		t.Error("File outside the root should not be modified")
+	}
+	
+	if err := ValidateFilePath(outside); err == nil {
+		t.Error("Scoped reads should not widen the global allowlist")
+	}
+}
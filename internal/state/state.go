@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 	
@@ -12,12 +13,30 @@ import (
 
 type State struct {
 	mu           sync.RWMutex
-	FilesChanged   int       `json:"files_changed"`
-	LinesAdded     int       `json:"lines_added"`
-	LinesRemoved   int       `json:"lines_removed"`
-	LastCommit     time.Time `json:"last_commit"`
-	LastPush       time.Time `json:"last_push"`
-	LastActivity   time.Time `json:"last_activity"`
+	FilesChanged   int                         `json:"files_changed"`
+	LinesAdded     int                         `json:"lines_added"`
+	LinesRemoved   int                         `json:"lines_removed"`
+	LastCommit     time.Time                   `json:"last_commit"`
+	LastPush       time.Time                   `json:"last_push"`
+	LastActivity   time.Time                   `json:"last_activity"`
+	ChangedFiles   []string                    `json:"changed_files,omitempty"`
+	HeadBranch     string                      `json:"head_branch,omitempty"`
+	HeadCommit     string                      `json:"head_commit,omitempty"`
+	UpstreamCommit string                      `json:"upstream_commit,omitempty"`
+	Suggestions    map[string]SuggestionRecord `json:"suggestions,omitempty"`
+	Held           map[string]HeldSuggestion   `json:"held,omitempty"`
+	SnoozeUntil    time.Time                   `json:"snooze_until"`
+	SnoozeCommit   bool                        `json:"snooze_until_commit,omitempty"`
+}
+
+type SuggestionRecord struct {
+	LastEmitted time.Time `json:"last_emitted"`
+	Value       int       `json:"value"`
+}
+
+type HeldSuggestion struct {
+	Reason       string          `json:"reason"`
+	Notification json.RawMessage `json:"notification"`
 }
 
 func DefaultState() *State {
@@ -76,6 +95,7 @@ func (s *State) Reset() {
 	s.FilesChanged = 0
 	s.LinesAdded = 0
 	s.LinesRemoved = 0
+	s.ChangedFiles = nil
 }
 
 func (s *State) RecordCommit() {
@@ -86,6 +106,9 @@ func (s *State) RecordCommit() {
 	s.FilesChanged = 0
 	s.LinesAdded = 0
 	s.LinesRemoved = 0
+	s.ChangedFiles = nil
+	s.Suggestions = nil
+	s.SnoozeCommit = false
 }
 
 func (s *State) RecordPush() {
@@ -93,6 +116,7 @@ func (s *State) RecordPush() {
 	defer s.mu.Unlock()
 	
 	s.LastPush = time.Now()
+	s.Suggestions = nil
 }
 
 func (s *State) IncrementFilesChanged() {
@@ -103,9 +127,186 @@ func (s *State) IncrementFilesChanged() {
 	s.LastActivity = time.Now()
 }
 
+func (s *State) RecordActivity() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.LastActivity = time.Now()
+}
+
+func (s *State) MarkFileChanged(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.LastActivity = time.Now()
+	
+	i := sort.SearchStrings(s.ChangedFiles, path)
+	if i < len(s.ChangedFiles) && s.ChangedFiles[i] == path {
+		return
+	}
+	
+	s.ChangedFiles = append(s.ChangedFiles, "")
+	copy(s.ChangedFiles[i+1:], s.ChangedFiles[i:])
+	s.ChangedFiles[i] = path
+	s.FilesChanged = len(s.ChangedFiles)
+}
+
+func (s *State) SetChangedFiles(paths []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	files := make([]string, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	
+	s.ChangedFiles = files
+	s.FilesChanged = len(files)
+}
+
+func (s *State) GetChangedFiles() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	files := make([]string, len(s.ChangedFiles))
+	copy(files, s.ChangedFiles)
+	
+	return files
+}
+
+func (s *State) SetLineStats(added, removed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.LinesAdded = added
+	s.LinesRemoved = removed
+}
+
+func (s *State) SetRefs(branch, head, upstream string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.HeadBranch = branch
+	s.HeadCommit = head
+	s.UpstreamCommit = upstream
+}
+
+func (s *State) GetRefs() (string, string, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	return s.HeadBranch, s.HeadCommit, s.UpstreamCommit
+}
+
+func (s *State) GetLastActivity() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	return s.LastActivity
+}
+
 func (s *State) GetStats() (int, int, int, time.Time, time.Time) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
 	return s.FilesChanged, s.LinesAdded, s.LinesRemoved, s.LastCommit, s.LastPush
-}
\ No newline at end of file
+}
+
+func (s *State) GetSuggestion(id string) (SuggestionRecord, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	record, ok := s.Suggestions[id]
+	return record, ok
+}
+
+func (s *State) RecordSuggestion(id string, value int, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if s.Suggestions == nil {
+		s.Suggestions = make(map[string]SuggestionRecord)
+	}
+	s.Suggestions[id] = SuggestionRecord{LastEmitted: at, Value: value}
+}
+
+func (s *State) PruneSuggestions(active map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	for id := range s.Suggestions {
+		if !active[id] {
+			delete(s.Suggestions, id)
+		}
+	}
+}
+
+func (s *State) Snooze(until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.SnoozeUntil = until
+	s.SnoozeCommit = false
+}
+
+func (s *State) SnoozeUntilCommit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.SnoozeUntil = time.Time{}
+	s.SnoozeCommit = true
+}
+
+func (s *State) ClearSnooze() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.SnoozeUntil = time.Time{}
+	s.SnoozeCommit = false
+}
+
+func (s *State) GetSnooze() (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	return s.SnoozeUntil, s.SnoozeCommit
+}
+
+func (s *State) IsSnoozed(now time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	return s.SnoozeCommit || now.Before(s.SnoozeUntil)
+}
+
+func (s *State) GetHeld(action string) (HeldSuggestion, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	held, ok := s.Held[action]
+	return held, ok
+}
+
+func (s *State) Hold(action string, held HeldSuggestion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if s.Held == nil {
+		s.Held = make(map[string]HeldSuggestion)
+	}
+	s.Held[action] = held
+}
+
+func (s *State) TakeHeld() map[string]HeldSuggestion {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	held := s.Held
+	s.Held = nil
+	return held
+}
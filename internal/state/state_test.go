@@ -3,6 +3,7 @@ package state
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDefaultState(t *testing.T) {
@@ -113,4 +114,66 @@ func TestStateRecordPush(t *testing.T) {
 	if lastPush.IsZero() {
 		t.Error("LastPush should be set after push")
 	}
-}
\ No newline at end of file
+}
+
+func TestStateDistinctChangedFiles(t *testing.T) {
+	state := DefaultState()
+	
+	for i := 0; i < 5; i++ {
+		state.MarkFileChanged("src/main.go")
+	}
+	state.MarkFileChanged("README.md")
+	
+	files, _, _, _, _ := state.GetStats()
+	if files != 2 {
+		t.Errorf("Expected 2 distinct files changed, got %d", files)
+	}
+	
+	state.SetChangedFiles([]string{"README.md"})
+	
+	files, _, _, _, _ = state.GetStats()
+	if files != 1 {
+		t.Errorf("Expected 1 file after reconcile, got %d", files)
+	}
+	
+	changed := state.GetChangedFiles()
+	if len(changed) != 1 || changed[0] != "README.md" {
+		t.Errorf("Unexpected changed files: %v", changed)
+	}
+	
+	state.RecordCommit()
+	
+	if len(state.GetChangedFiles()) != 0 {
+		t.Error("Changed files should be cleared after commit")
+	}
+}
+
+func TestStateSnooze(t *testing.T) {
+	state := DefaultState()
+	now := time.Now()
+	
+	if state.IsSnoozed(now) {
+		t.Error("New state should not be snoozed")
+	}
+	
+	state.Snooze(now.Add(time.Hour))
+	if !state.IsSnoozed(now) || state.IsSnoozed(now.Add(2*time.Hour)) {
+		t.Error("Timed snooze should expire after its deadline")
+	}
+	
+	state.SnoozeUntilCommit()
+	if !state.IsSnoozed(now.Add(48 * time.Hour)) {
+		t.Error("Snooze until commit should not expire on its own")
+	}
+	
+	state.RecordSuggestion("files_changed", 5, now)
+	state.RecordCommit()
+	
+	if state.IsSnoozed(now) {
+		t.Error("Commit should end an until-commit snooze")
+	}
+	
+	if _, ok := state.GetSuggestion("files_changed"); ok {
+		t.Error("Commit should clear emitted suggestion records")
+	}
+}
@@ -0,0 +1,204 @@
+package supervisor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gitsentry/internal/core"
+	"gitsentry/internal/logger"
+	"gitsentry/internal/monitor"
+	"gitsentry/internal/registry"
+	"gitsentry/internal/security"
+)
+
+const DefaultInterval = 30 * time.Second
+
+type Supervisor struct {
+	registryPath string
+	interval     time.Duration
+	budget       *monitor.Budget
+	log          *logger.Logger
+	mu           sync.Mutex
+	instances    map[string]*core.GitSentry
+	failed       map[string]string
+}
+
+func New(registryPath string, log *logger.Logger) *Supervisor {
+	return &Supervisor{
+		registryPath: registryPath,
+		interval:     DefaultInterval,
+		budget:       monitor.DefaultBudget(),
+		log:          log,
+		instances:    make(map[string]*core.GitSentry),
+		failed:       make(map[string]string),
+	}
+}
+
+func (s *Supervisor) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Sync(); err != nil {
+				s.log.Error(err.Error())
+			}
+			s.tick()
+		case <-stop:
+			s.StopAll()
+			return
+		}
+	}
+}
+
+func (s *Supervisor) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	reg, err := registry.LoadFrom(s.registryPath)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+	
+	wanted := make(map[string]bool)
+	for _, path := range reg.Paths() {
+		wanted[path] = true
+	}
+	
+	for path, gs := range s.instances {
+		if !wanted[path] {
+			gs.Stop()
+			delete(s.instances, path)
+			s.log.Info(fmt.Sprintf("stopped monitoring %s", path))
+		}
+	}
+	
+	for path := range s.failed {
+		if !wanted[path] {
+			delete(s.failed, path)
+		}
+	}
+	
+	for path := range wanted {
+		if _, ok := s.instances[path]; ok {
+			continue
+		}
+		
+		gs, err := s.startInstance(path)
+		if err != nil {
+			if s.failed[path] != err.Error() {
+				s.log.Error(fmt.Sprintf("failed to monitor %s: %v", path, err))
+			}
+			s.failed[path] = err.Error()
+			continue
+		}
+		
+		delete(s.failed, path)
+		s.instances[path] = gs
+		s.log.Info(fmt.Sprintf("started monitoring %s", path))
+	}
+	
+	return nil
+}
+
+func (s *Supervisor) startInstance(path string) (*core.GitSentry, error) {
+	if _, err := os.Stat(filepath.Join(path, ".gitsentry")); err != nil {
+		return nil, fmt.Errorf("GitSentry is not initialized (run 'gitsentry init')")
+	}
+	
+	if err := security.AllowRoot(path); err != nil {
+		return nil, err
+	}
+	
+	gs := core.NewGitSentryWithOptions(path, core.Options{
+		WatchBudget:       s.budget,
+		ExternalScheduler: true,
+		OnShutdown: func() {
+			go s.Unhost(path)
+		},
+	})
+	
+	if err := gs.Start(); err != nil {
+		return nil, err
+	}
+	
+	return gs, nil
+}
+
+func (s *Supervisor) Unhost(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	reg, err := registry.LoadFrom(s.registryPath)
+	if err != nil {
+		s.log.Error(fmt.Sprintf("failed to load registry: %v", err))
+	} else if _, removed := reg.Remove(path); removed {
+		if err := reg.Save(); err != nil {
+			s.log.Error(fmt.Sprintf("failed to unregister %s: %v", path, err))
+		}
+	}
+	
+	gs, ok := s.instances[path]
+	if !ok {
+		return
+	}
+	
+	if err := gs.Stop(); err != nil {
+		s.log.Error(fmt.Sprintf("failed to stop %s cleanly: %v", path, err))
+	}
+	delete(s.instances, path)
+	s.log.Info(fmt.Sprintf("stopped monitoring %s on request", path))
+}
+
+func (s *Supervisor) tick() {
+	for _, gs := range s.Instances() {
+		gs.Tick()
+	}
+}
+
+func (s *Supervisor) Instances() []*core.GitSentry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	paths := make([]string, 0, len(s.instances))
+	for path := range s.instances {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	
+	instances := make([]*core.GitSentry, len(paths))
+	for i, path := range paths {
+		instances[i] = s.instances[path]
+	}
+	
+	return instances
+}
+
+func (s *Supervisor) Failures() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	failures := make(map[string]string, len(s.failed))
+	for path, reason := range s.failed {
+		failures[path] = reason
+	}
+	
+	return failures
+}
+
+func (s *Supervisor) StopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	for path, gs := range s.instances {
+		if err := gs.Stop(); err != nil {
+			s.log.Error(fmt.Sprintf("failed to stop %s cleanly: %v", path, err))
+		}
+		delete(s.instances, path)
+	}
+}
@@ -0,0 +1,115 @@
+package supervisor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitsentry/internal/logger"
+	"gitsentry/internal/registry"
+)
+
+func TestSyncFollowsRegistry(t *testing.T) {
+	tempDir := "test_supervisor"
+	os.MkdirAll(tempDir, 0755)
+	defer os.RemoveAll(tempDir)
+	
+	root, _ := filepath.Abs(tempDir)
+	registryPath := filepath.Join(root, "config", "repos.yaml")
+	
+	reg, err := registry.LoadFrom(registryPath)
+	if err != nil {
+		t.Fatalf("Failed to load registry: %v", err)
+	}
+	
+	var repos []string
+	for _, name := range []string{"alpha", "beta"} {
+		repo := filepath.Join(root, name)
+		os.MkdirAll(filepath.Join(repo, ".gitsentry"), 0755)
+		os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".gitsentry/\n"), 0644)
+		
+		cmd := exec.Command("git", "init", "-q")
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git init failed: %v\n%s", err, output)
+		}
+		
+		path, err := reg.Add(repo)
+		if err != nil {
+			t.Fatalf("Failed to register %s: %v", repo, err)
+		}
+		repos = append(repos, path)
+	}
+	
+	uninitialized := filepath.Join(root, "gamma")
+	os.MkdirAll(uninitialized, 0755)
+	reg.Add(uninitialized)
+	
+	if err := reg.Save(); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+	
+	log, err := logger.NewLogger(filepath.Join(root, "config"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer log.Close()
+	
+	sup := New(registryPath, log)
+	defer sup.StopAll()
+	
+	if err := sup.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	
+	if len(sup.Instances()) != 2 {
+		t.Fatalf("Expected 2 hosted repositories, got %d", len(sup.Instances()))
+	}
+	
+	if len(sup.Failures()) != 1 {
+		t.Errorf("Expected the uninitialized repository to be reported, got %v", sup.Failures())
+	}
+	
+	used, _ := sup.budget.Usage()
+	if used == 0 {
+		t.Error("Hosted repositories should draw from the shared watch budget")
+	}
+	
+	sup.tick()
+	
+	sup.Instances()[0].RequestShutdown()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(sup.Instances()) != 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	
+	instances := sup.Instances()
+	if len(instances) != 1 || instances[0].RepoPath() != repos[1] {
+		t.Fatalf("A hosted repository's shutdown request should stop only that repository, still hosting %d", len(instances))
+	}
+	
+	if err := sup.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(sup.Instances()) != 1 {
+		t.Error("Sync should not restart a repository stopped on request")
+	}
+	
+	reg, _ = registry.LoadFrom(registryPath)
+	reg.Remove(repos[1])
+	reg.Save()
+	
+	if err := sup.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	
+	if len(sup.Instances()) != 0 {
+		t.Error("Removing a repository from the registry should stop it")
+	}
+	
+	if used, _ := sup.budget.Usage(); used != 0 {
+		t.Errorf("Stopping every repository should release all of its watches, %d still in use", used)
+	}
+}